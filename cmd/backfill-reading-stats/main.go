@@ -0,0 +1,49 @@
+// cmd/backfill-reading-stats/main.go
+//
+// 为已有笔记回填字数（word_count）与阅读时间（reading_minutes），计算规则与保存笔记时相同。
+//
+//	go run ./cmd/backfill-reading-stats [-all] [-batch 500]
+//
+// 默认只处理正文非空且尚未统计（word_count 为 0）的笔记，可重复执行；-all 重新计算全部笔记（例如统计规则调整后）。
+// 只更新这两列，不修改 updated_at 与版本号。数据库配置与服务端相同（读取环境变量或 .env）。
+// Redis 中已缓存的笔记在缓存过期（5 分钟）后才会返回新值。
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"HYH-Blog-Gin/internal/config"
+	"HYH-Blog-Gin/internal/database"
+	"HYH-Blog-Gin/internal/repository"
+)
+
+func main() {
+	all := flag.Bool("all", false, "重新计算全部笔记，而不只是尚未统计的笔记")
+	batch := flag.Int("batch", 500, "每批处理的笔记数量")
+	flag.Parse()
+	if *batch <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg := config.Load()
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		log.Fatalf("连接数据库失败: %v", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Printf("关闭数据库失败: %v", err)
+		}
+	}()
+
+	updated, err := repository.BackfillReadingStats(db.DB, *all, *batch, func(scanned, updated int) {
+		log.Printf("已处理 %d 篇，更新 %d 篇", scanned, updated)
+	})
+	if err != nil {
+		log.Fatalf("回填失败（已更新 %d 篇）: %v", updated, err)
+	}
+	log.Printf("回填完成，共更新 %d 篇笔记", updated)
+}
@@ -0,0 +1,107 @@
+// cmd/import/main.go
+//
+// 从 Hexo/Hugo 导出的 Markdown 压缩包导入笔记，与 POST /api/v1/notes/import 使用同一套导入逻辑。
+//
+//	go run ./cmd/import -user alice -file posts.zip [-dry-run]
+//
+// 数据库、Redis、图片转换服务与上传目录的配置与服务端相同（读取环境变量或 .env）。导入报告以 JSON 输出到标准输出，
+// 有文件导入失败时以状态码 1 退出。
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"HYH-Blog-Gin/internal/cache"
+	"HYH-Blog-Gin/internal/config"
+	"HYH-Blog-Gin/internal/database"
+	"HYH-Blog-Gin/internal/grpcclient"
+	"HYH-Blog-Gin/internal/repository"
+	"HYH-Blog-Gin/internal/services"
+)
+
+func main() {
+	username := flag.String("user", "", "导入到该用户名下（必填）")
+	file := flag.String("file", "", "Markdown 压缩包路径（zip，必填）")
+	dryRun := flag.Bool("dry-run", false, "只解析与校验，不创建笔记也不上传图片")
+	flag.Parse()
+	if *username == "" || *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg := config.Load()
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		log.Fatalf("连接数据库失败: %v", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Printf("关闭数据库失败: %v", err)
+		}
+	}()
+
+	user, err := repository.NewUserRepository(db.DB).FindByUsername(*username)
+	if err != nil || user == nil || user.ID == 0 {
+		log.Fatalf("用户不存在: %s", *username)
+	}
+
+	// 与服务端一样包装缓存仓储：新建笔记后递增公开内容缓存代数，订阅源与站点地图随之刷新
+	noteCache := cache.NewNoOpCache()
+	if redisClient, err := database.NewRedisClient(cfg); err != nil {
+		log.Printf("连接 Redis 失败，订阅源与站点地图的缓存将在过期后才包含导入的笔记: %v", err)
+	} else {
+		defer func() {
+			if err := redisClient.Close(); err != nil {
+				log.Printf("关闭 Redis 失败: %v", err)
+			}
+		}()
+		noteCache = cache.NewRedisCache(redisClient)
+	}
+	noteRepo := repository.NewCachedNoteRepository(repository.NewNoteRepository(db.DB, cfg.Search), noteCache, 5*time.Minute)
+	imageRepo := repository.NewImageRepository(db.DB)
+
+	addr := cfg.Server.ImageGRPCAddr
+	if addr == "" {
+		addr = cfg.Server.GRPCAddr
+	}
+	grpcClient, grpcCleanup, err := grpcclient.NewImageClient(context.Background(), addr)
+	if err != nil {
+		log.Printf("图片 gRPC 客户端创建失败，引用本地图片的文件将导入失败: %v", err)
+		grpcClient, grpcCleanup = nil, func() {}
+	}
+	defer grpcCleanup()
+	if err := os.MkdirAll(cfg.Server.UploadDir, 0o755); err != nil {
+		log.Fatalf("创建上传目录失败: %v", err)
+	}
+	storage := services.NewLocalStorage(cfg.Server.UploadDir, cfg.Server.UploadURLPrefix)
+	imageSvc := services.NewImageService(grpcClient, storage, 80, imageRepo)
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("打开压缩包失败: %v", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		log.Fatalf("读取压缩包失败: %v", err)
+	}
+
+	report, err := services.NewNoteImportService(noteRepo, imageSvc).Import(user.ID, f, info.Size(), *dryRun)
+	if err != nil {
+		log.Fatalf("导入失败: %v", err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Fatalf("输出导入报告失败: %v", err)
+	}
+	log.Printf("导入完成: imported=%d failed=%d dry_run=%v", report.Imported, report.Failed, report.DryRun)
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"HYH-Blog-Gin/internal/repository"
 	"HYH-Blog-Gin/internal/router"
 	"HYH-Blog-Gin/internal/services"
+	"HYH-Blog-Gin/internal/trending"
 	"HYH-Blog-Gin/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -32,6 +33,7 @@ type Application struct {
 	Database     *database.DB
 	Redis        *redis.Client
 	Cache        cache.Cache
+	Trending     trending.Tracker
 	JWTService   *auth.JWTService
 	Services     *ServiceContainer
 	Handlers     *HandlerContainer
@@ -46,6 +48,28 @@ type ServiceContainer struct {
 	NoteService  services.NoteService
 	TagService   services.TagService
 	ImageService services.ImageService
+	// RevisionService 笔记修订历史
+	RevisionService services.NoteRevisionService
+	// CommentService 笔记评论
+	CommentService services.CommentService
+	// BookmarkService 收藏（阅读列表）
+	BookmarkService services.BookmarkService
+	// SeriesService 笔记系列
+	SeriesService services.SeriesService
+	// LinkService 笔记出链与反向链接
+	LinkService services.NoteLinkService
+	// ImportService 从 Markdown 压缩包导入笔记
+	ImportService services.NoteImportService
+	// ExportService 导出笔记为 Markdown 压缩包
+	ExportService services.NoteExportService
+	// FeedService RSS/Atom 订阅源
+	FeedService services.FeedService
+	// SitemapService 站点地图与 robots.txt
+	SitemapService services.SitemapService
+	// TrendingService 热度排行
+	TrendingService services.TrendingService
+	// ShareService 笔记分享链接
+	ShareService services.NoteShareService
 }
 
 // HandlerContainer 处理器容器
@@ -54,6 +78,26 @@ type HandlerContainer struct {
 	NoteHandler  *handlers.NoteHandler
 	TagHandler   *handlers.TagHandler
 	ImageHandler *handlers.ImageHandler
+	// RevisionHandler 笔记修订历史
+	RevisionHandler *handlers.NoteRevisionHandler
+	// CommentHandler 笔记评论
+	CommentHandler *handlers.CommentHandler
+	// BookmarkHandler 收藏（阅读列表）
+	BookmarkHandler *handlers.BookmarkHandler
+	// SeriesHandler 笔记系列
+	SeriesHandler *handlers.SeriesHandler
+	// LinkHandler 笔记出链与反向链接
+	LinkHandler *handlers.NoteLinkHandler
+	// ImportHandler 从 Markdown 压缩包导入笔记
+	ImportHandler *handlers.NoteImportHandler
+	// ExportHandler 导出笔记为 Markdown 压缩包
+	ExportHandler *handlers.NoteExportHandler
+	// FeedHandler RSS/Atom 订阅源
+	FeedHandler *handlers.FeedHandler
+	// SitemapHandler 站点地图与 robots.txt
+	SitemapHandler *handlers.SitemapHandler
+	// ShareHandler 笔记分享链接
+	ShareHandler *handlers.NoteShareHandler
 }
 
 // InitializeApplication 初始化应用的所有组件
@@ -130,17 +174,40 @@ func (app *Application) initializeServices() {
 
 	// 仓储
 	userRepo := repository.NewUserRepository(app.Database.DB)
-	noteRepoBase := repository.NewNoteRepository(app.Database.DB)
+	noteRepoBase := repository.NewNoteRepository(app.Database.DB, app.Config.Search)
 	// 包装缓存仓储
 	noteRepo := repository.NewCachedNoteRepository(noteRepoBase, app.Cache, 5*time.Minute)
 	tagRepo := repository.NewTagRepository(app.Database.DB)
 	imageRepo := repository.NewImageRepository(app.Database.DB)
+	revisionRepo := repository.NewNoteRevisionRepository(app.Database.DB)
+	commentRepo := repository.NewCommentRepository(app.Database.DB)
+	likeRepo := repository.NewNoteLikeRepository(app.Database.DB)
+	bookmarkRepo := repository.NewBookmarkRepository(app.Database.DB)
+	seriesRepo := repository.NewSeriesRepository(app.Database.DB)
+	linkRepo := repository.NewNoteLinkRepository(app.Database.DB)
+	sitemapRepo := repository.NewSitemapRepository(app.Database.DB)
+	shareRepo := repository.NewNoteShareRepository(app.Database.DB)
+
+	// 热度排行依赖 Redis 有序集合，未连接 Redis 时排行为空
+	app.Trending = trending.NewNoOpTracker()
+	if app.Redis != nil {
+		app.Trending = trending.NewRedisTracker(app.Redis)
+	}
 
 	app.Services = &ServiceContainer{
-		UserService:  services.NewUserService(userRepo),
-		NoteService:  services.NewNoteService(noteRepo),
-		TagService:   services.NewTagService(tagRepo),
-		ImageService: services.NewImageService(nil, nil, 80, imageRepo), // will be replaced below
+		UserService:     services.NewUserService(userRepo),
+		NoteService:     services.NewNoteService(noteRepo, imageRepo, likeRepo, bookmarkRepo, seriesRepo),
+		TagService:      services.NewTagService(tagRepo),
+		ImageService:    services.NewImageService(nil, nil, 80, imageRepo), // will be replaced below
+		RevisionService: services.NewNoteRevisionService(noteRepo, revisionRepo),
+		CommentService:  services.NewCommentService(noteRepo, commentRepo),
+		BookmarkService: services.NewBookmarkService(noteRepo, bookmarkRepo),
+		SeriesService:   services.NewSeriesService(noteRepo, seriesRepo),
+		LinkService:     services.NewNoteLinkService(noteRepo, linkRepo),
+		FeedService:     services.NewFeedService(noteRepo, userRepo, siteSettings(app.Config.Site), app.Config.Feed.FullContent, app.Config.Feed.Limit),
+		SitemapService:  services.NewSitemapService(sitemapRepo, siteSettings(app.Config.Site), robotsSettings(app.Config.Sitemap)),
+		TrendingService: services.NewTrendingService(noteRepo, app.Trending),
+		ShareService:    services.NewNoteShareService(noteRepo, shareRepo),
 	}
 
 	// 初始化 image service (may use grpc client)
@@ -166,31 +233,71 @@ func (app *Application) initializeServices() {
 	}
 	storage := services.NewLocalStorage(uploadDir, uploadURL)
 	app.Services.ImageService = services.NewImageService(grpcClient, storage, 80, imageRepo)
+	// 导入需要上传图片，在最终的 image service 创建后初始化
+	app.Services.ImportService = services.NewNoteImportService(noteRepo, app.Services.ImageService)
+	app.Services.ExportService = services.NewNoteExportService(noteRepo, storage)
+}
+
+// siteSettings 把站点配置转换为服务层使用的 SiteSettings
+func siteSettings(c config.SiteConfig) services.SiteSettings {
+	return services.SiteSettings{URL: c.URL, Title: c.Title, Description: c.Description, NotePath: c.NotePath, UserPath: c.UserPath, TagPath: c.TagPath}
+}
+
+// robotsSettings 把站点地图配置中的 robots.txt 部分转换为服务层使用的 RobotsSettings
+func robotsSettings(c config.SitemapConfig) services.RobotsSettings {
+	return services.RobotsSettings{Disallow: c.RobotsDisallow, Text: c.RobotsTxt}
 }
 
 // initializeHandlers 初始化HTTP处理器
 func (app *Application) initializeHandlers() {
 	app.Handlers = &HandlerContainer{
-		UserHandler:  handlers.NewUserHandler(app.Services.UserService, app.JWTService),
-		NoteHandler:  handlers.NewNoteHandler(app.Services.NoteService, app.Cache),
-		TagHandler:   handlers.NewTagHandler(app.Services.TagService),
-		ImageHandler: handlers.NewImageHandler(app.Services.ImageService),
+		UserHandler:     handlers.NewUserHandler(app.Services.UserService, app.JWTService),
+		NoteHandler:     handlers.NewNoteHandler(app.Services.NoteService, app.Cache, app.Services.TrendingService),
+		TagHandler:      handlers.NewTagHandler(app.Services.TagService),
+		ImageHandler:    handlers.NewImageHandler(app.Services.ImageService),
+		RevisionHandler: handlers.NewNoteRevisionHandler(app.Services.RevisionService),
+		CommentHandler:  handlers.NewCommentHandler(app.Services.CommentService),
+		BookmarkHandler: handlers.NewBookmarkHandler(app.Services.BookmarkService),
+		SeriesHandler:   handlers.NewSeriesHandler(app.Services.SeriesService),
+		LinkHandler:     handlers.NewNoteLinkHandler(app.Services.LinkService),
+		ImportHandler:   handlers.NewNoteImportHandler(app.Services.ImportService),
+		ExportHandler:   handlers.NewNoteExportHandler(app.Services.ExportService),
+		FeedHandler:     handlers.NewFeedHandler(app.Services.FeedService, app.Cache, time.Duration(app.Config.Feed.CacheTTLSeconds)*time.Second),
+		SitemapHandler:  handlers.NewSitemapHandler(app.Services.SitemapService, app.Cache, time.Duration(app.Config.Sitemap.CacheTTLSeconds)*time.Second),
+		ShareHandler:    handlers.NewNoteShareHandler(app.Services.ShareService),
 	}
 }
 
 // initializeRouterAndServer 初始化路由和HTTP服务器
 func (app *Application) initializeRouterAndServer() {
-	app.Router = router.SetupRouter(app.Config, app.JWTService, app.Handlers.UserHandler, app.Handlers.NoteHandler, app.Handlers.ImageHandler, app.Handlers.TagHandler, app.Database, app.Redis)
+	app.Router = router.SetupRouter(app.Config, app.JWTService, app.Handlers.UserHandler, app.Handlers.NoteHandler, app.Handlers.ImageHandler, app.Handlers.TagHandler, app.Handlers.RevisionHandler, app.Handlers.CommentHandler, app.Handlers.BookmarkHandler, app.Handlers.SeriesHandler, app.Handlers.LinkHandler, app.Handlers.ImportHandler, app.Handlers.ExportHandler, app.Handlers.FeedHandler, app.Handlers.SitemapHandler, app.Handlers.ShareHandler, app.Database, app.Redis)
 	app.Server = &http.Server{Addr: ":" + app.Config.Server.Port, Handler: app.Router}
 }
 
-// startBackgroundTasks 启动后台任务（如计数器同步）
+// startBackgroundTasks 启动后台任务（如计数器同步、定时发布、回收站清理、热度排行）
 func (app *Application) startBackgroundTasks() {
+	publisherCtx, cancelPublisher := context.WithCancel(context.Background())
+	app.registerCleanup(cancelPublisher)
+	go StartNotePublisher(publisherCtx, app.Database.DB, app.Cache, 30*time.Second)
+	log.Println("定时发布任务已启动")
+
+	if days := app.Config.Trash.RetentionDays; days > 0 {
+		trashCtx, cancelTrash := context.WithCancel(context.Background())
+		app.registerCleanup(cancelTrash)
+		go StartTrashPurger(trashCtx, app.Database.DB, app.Cache, days, time.Hour)
+		log.Println("回收站清理任务已启动")
+	}
+
 	if app.Redis != nil {
 		ctx, cancel := context.WithCancel(context.Background())
 		app.registerCleanup(cancel)
 		go StartCounterSync(ctx, app.Database.DB, app.Cache, 10*time.Second)
 		log.Println("计数器同步任务已启动")
+
+		trendingCtx, cancelTrending := context.WithCancel(context.Background())
+		app.registerCleanup(cancelTrending)
+		go StartTrending(trendingCtx, app.Database.DB, app.Trending, time.Hour)
+		log.Println("热度排行任务已启动")
 	}
 }
 
@@ -12,7 +12,7 @@ import (
 	"gorm.io/gorm"
 )
 
-// StartCounterSync 启动一个后台 worker，定期把 Redis 中的 views/likes 增量同步回 Postgres。
+// StartCounterSync 启动一个后台 worker，定期把 Redis 中的 views 增量同步回 Postgres，并按 note_likes 重新统计有变化笔记的 likes。
 func StartCounterSync(ctx context.Context, gormDB *gorm.DB, c cache.Cache, interval time.Duration) {
 	if c == nil || gormDB == nil {
 		log.Println("counter sync: missing dependency, not started")
@@ -76,8 +76,9 @@ func doSyncOnce(ctx context.Context, gormDB *gorm.DB, c cache.Cache) error {
 				}
 			}
 			if likes != 0 {
+				// 点赞以 note_likes 为准：增量仅表示有变化，直接按点赞记录重新统计，避免重复/丢失计数
 				if err := tx.Model(&models.Note{}).Where("id = ?", id).
-					UpdateColumn("likes", gorm.Expr("likes + ?", likes)).Error; err != nil {
+					UpdateColumn("likes", gorm.Expr("(SELECT COUNT(*) FROM note_likes WHERE note_likes.note_id = ?)", id)).Error; err != nil {
 					return err
 				}
 			}
@@ -88,7 +89,7 @@ func doSyncOnce(ctx context.Context, gormDB *gorm.DB, c cache.Cache) error {
 			log.Printf("counter sync: failed to update DB for id=%d (views=%d likes=%d): %v", id, views, likes, err)
 			// restore counts back to cache so we'll retry later
 			if views != 0 {
-				if _, ierr := c.Increment(ctx, cache.NewKeyGenerator().NoteLikes(id), views); ierr != nil {
+				if _, ierr := c.Increment(ctx, cache.NewKeyGenerator().NoteViews(id), views); ierr != nil {
 					log.Printf("counter sync: failed to restore views to redis for id=%d: %v", id, ierr)
 				}
 			}
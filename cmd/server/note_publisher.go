@@ -0,0 +1,84 @@
+// cmd/server/note_publisher.go
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"HYH-Blog-Gin/internal/cache"
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StartNotePublisher 启动一个后台 worker，定期发布到期的定时笔记并下线已过期的笔记。
+// 状态切换使用单条 UPDATE ... RETURNING 完成，多实例同时运行时同一笔记只会被处理一次。
+func StartNotePublisher(ctx context.Context, gormDB *gorm.DB, c cache.Cache, interval time.Duration) {
+	if gormDB == nil {
+		log.Println("note publisher: missing dependency, not started")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	log.Println("note publisher: started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("note publisher: stopping")
+			return
+		case <-ticker.C:
+			if err := doPublishOnce(ctx, gormDB, c); err != nil {
+				log.Printf("note publisher: pass error: %v", err)
+			}
+		}
+	}
+}
+
+// doPublishOnce 执行一次发布/下线任务（发布状态变化同样递增乐观锁版本号），并失效受影响笔记与订阅源的缓存
+func doPublishOnce(ctx context.Context, gormDB *gorm.DB, c cache.Cache) error {
+	db := gormDB.WithContext(ctx)
+
+	var published []models.Note
+	if err := db.Model(&published).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("status = ? AND publish_at <= now()", models.NoteStatusScheduled).
+		Updates(map[string]interface{}{
+			"status":       models.NoteStatusPublished,
+			"is_public":    true,
+			"published_at": gorm.Expr("COALESCE(published_at, publish_at)"),
+			"publish_at":   nil,
+			"version":      gorm.Expr("version + 1"),
+		}).Error; err != nil {
+		return err
+	}
+
+	var expired []models.Note
+	if err := db.Model(&expired).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("status = ? AND expires_at <= now()", models.NoteStatusPublished).
+		Updates(map[string]interface{}{
+			"status":    models.NoteStatusUnpublished,
+			"is_public": false,
+			"version":   gorm.Expr("version + 1"),
+		}).Error; err != nil {
+		return err
+	}
+
+	for _, n := range append(published, expired...) {
+		if c != nil {
+			_ = c.Delete(ctx, cache.NewKeyGenerator().Note(n.ID))
+		}
+	}
+	if len(published) > 0 || len(expired) > 0 {
+		// 公开笔记集合发生变化，订阅源、站点地图与相关阅读候选需要重新生成
+		if c != nil {
+			_, _ = c.Increment(ctx, cache.PublicGenerationKey, 1)
+		}
+		log.Printf("note publisher: published=%d expired=%d", len(published), len(expired))
+	}
+	return nil
+}
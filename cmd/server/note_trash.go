@@ -0,0 +1,80 @@
+// cmd/server/note_trash.go
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"HYH-Blog-Gin/internal/cache"
+	"HYH-Blog-Gin/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// trashPurgeBatch 每批彻底删除的笔记数量
+const trashPurgeBatch = 100
+
+// StartTrashPurger 启动一个后台 worker，定期彻底删除在回收站中超过 retentionDays 天的笔记。
+func StartTrashPurger(ctx context.Context, gormDB *gorm.DB, c cache.Cache, retentionDays int, interval time.Duration) {
+	if gormDB == nil || retentionDays <= 0 {
+		log.Println("trash purger: missing dependency or retention disabled, not started")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	log.Println("trash purger: started")
+
+	// 启动时先执行一次，避免重启频繁时迟迟不清理
+	if err := doPurgeTrashOnce(ctx, gormDB, c, retentionDays); err != nil {
+		log.Printf("trash purger: pass error: %v", err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("trash purger: stopping")
+			return
+		case <-ticker.C:
+			if err := doPurgeTrashOnce(ctx, gormDB, c, retentionDays); err != nil {
+				log.Printf("trash purger: pass error: %v", err)
+			}
+		}
+	}
+}
+
+// doPurgeTrashOnce 分批彻底删除过期的回收站笔记（含 note_tags 关联），并清理这些笔记残留的缓存键
+func doPurgeTrashOnce(ctx context.Context, gormDB *gorm.DB, c cache.Cache, retentionDays int) error {
+	db := gormDB.WithContext(ctx)
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+	keys := cache.NewKeyGenerator()
+
+	purged := 0
+	for {
+		ids, err := repository.TrashedBefore(db, cutoff, trashPurgeBatch)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			break
+		}
+		if err := repository.PurgeNotes(db, ids); err != nil {
+			return err
+		}
+		if c != nil {
+			for _, id := range ids {
+				for _, key := range keys.NoteAll(id) {
+					_ = c.Delete(ctx, key)
+				}
+			}
+		}
+		purged += len(ids)
+		if len(ids) < trashPurgeBatch || ctx.Err() != nil {
+			break
+		}
+	}
+	if purged > 0 {
+		log.Printf("trash purger: purged=%d", purged)
+	}
+	return nil
+}
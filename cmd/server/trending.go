@@ -0,0 +1,69 @@
+// cmd/server/trending.go
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"HYH-Blog-Gin/internal/repository"
+	"HYH-Blog-Gin/internal/trending"
+
+	"gorm.io/gorm"
+)
+
+// trendingRebuildBatch 重建热度排行时每批读取的笔记数量
+const trendingRebuildBatch = 1000
+
+// StartTrending 启动热度排行的后台 worker：启动时从数据库重建排行（多个实例只重建一次），之后每隔 interval 推进一次基准时间。
+func StartTrending(ctx context.Context, gormDB *gorm.DB, tracker trending.Tracker, interval time.Duration) {
+	if gormDB == nil || tracker == nil {
+		log.Println("trending: missing dependency, not started")
+		return
+	}
+
+	if err := rebuildTrending(ctx, gormDB, tracker); err != nil {
+		log.Printf("trending: rebuild error: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("trending: stopping")
+			return
+		case <-ticker.C:
+			if err := tracker.Rebase(ctx); err != nil {
+				log.Printf("trending: rebase error: %v", err)
+			}
+		}
+	}
+}
+
+// rebuildTrending 以数据库中公开笔记的阅读量与点赞数重建排行，历史热度按发布时间计入；
+// 多个实例同时启动时只有取得重建锁的实例重建，其余实例跳过。
+func rebuildTrending(ctx context.Context, gormDB *gorm.DB, tracker trending.Tracker) error {
+	var count int
+	err := tracker.Rebuild(ctx, func(ctx context.Context) ([]trending.Seed, error) {
+		var seeds []trending.Seed
+		err := repository.EachPublicNoteCounters(gormDB.WithContext(ctx), trendingRebuildBatch, func(batch []repository.PublicNoteCounters) error {
+			for _, n := range batch {
+				seeds = append(seeds, trending.Seed{NoteID: n.ID, Points: trending.Points(n.Views, n.Likes), At: n.PublishedAt})
+			}
+			return ctx.Err()
+		})
+		count = len(seeds)
+		return seeds, err
+	})
+	if errors.Is(err, trending.ErrRebuildSkipped) {
+		log.Println("trending: rebuild skipped, another instance holds the lock")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("trending: rebuilt from %d notes", count)
+	return nil
+}
@@ -42,6 +42,16 @@ const (
 	KeySuffixViews = "views"
 	// KeySuffixLikes 点赞数计数器后缀
 	KeySuffixLikes = "likes"
+	// KeySuffixRendered 渲染后的 Markdown（HTML 与目录）后缀
+	KeySuffixRendered = "rendered"
+	// KeySuffixRelated 相关笔记候选列表后缀
+	KeySuffixRelated = "related"
+	// KeyPrefixFeed 订阅源 XML 缓存键前缀
+	KeyPrefixFeed = "feed:"
+	// KeyPrefixSitemap 站点地图 XML 缓存键前缀
+	KeyPrefixSitemap = "sitemap:"
+	// PublicGenerationKey 公开内容缓存代数，任一笔记变化时递增，使所有已缓存的订阅源与站点地图失效
+	PublicGenerationKey = "public:generation"
 )
 
 // KeyGenerator 缓存键生成器
@@ -67,6 +77,32 @@ func (kg *KeyGenerator) NoteLikes(id uint) string {
 	return fmt.Sprintf("%s%d:%s", KeyPrefixNote, id, KeySuffixLikes)
 }
 
+// NoteRendered 生成笔记渲染结果缓存键
+func (kg *KeyGenerator) NoteRendered(id uint) string {
+	return fmt.Sprintf("%s%d:%s", KeyPrefixNote, id, KeySuffixRendered)
+}
+
+// NoteRelated 生成相关笔记候选列表缓存键，gen 为当前的公开内容缓存代数（候选笔记的可见性变化后即失效）
+func (kg *KeyGenerator) NoteRelated(gen int64, id uint) string {
+	return fmt.Sprintf("%s%d:%s:%d", KeyPrefixNote, id, KeySuffixRelated, gen)
+}
+
+// NoteAll 返回单篇笔记相关的全部缓存键（笔记、渲染结果与计数器），用于彻底删除笔记后清理；
+// 相关笔记候选列表按代数区分，代数递增后自然失效，在 TTL 到期后清除
+func (kg *KeyGenerator) NoteAll(id uint) []string {
+	return []string{kg.Note(id), kg.NoteRendered(id), kg.NoteViews(id), kg.NoteLikes(id)}
+}
+
+// Feed 生成订阅源缓存键，gen 为当前的公开内容缓存代数，scope 为 site/user/tag，value 为作者用户名或标签名
+func (kg *KeyGenerator) Feed(gen int64, format, scope, value string) string {
+	return fmt.Sprintf("%s%d:%s:%s:%s", KeyPrefixFeed, gen, format, scope, value)
+}
+
+// Sitemap 生成站点地图缓存键，gen 为当前的公开内容缓存代数，page 为 0 时表示 /sitemap.xml 本身
+func (kg *KeyGenerator) Sitemap(gen int64, page int) string {
+	return fmt.Sprintf("%s%d:%d", KeyPrefixSitemap, gen, page)
+}
+
 // RedisCache 基于Redis的缓存实现
 type RedisCache struct {
 	client *redis.Client
@@ -19,6 +19,21 @@ type Config struct {
 
 	// RateLimit 包含各接口的限流配置。
 	RateLimit RateLimitConfig
+
+	// Search 包含全文检索配置。
+	Search SearchConfig
+
+	// Trash 包含回收站配置。
+	Trash TrashConfig
+
+	// Site 包含站点地址与展示信息。
+	Site SiteConfig
+
+	// Feed 包含 RSS/Atom 订阅源配置。
+	Feed FeedConfig
+
+	// Sitemap 包含站点地图与 robots.txt 配置。
+	Sitemap SitemapConfig
 }
 
 // Load 尝试从项目根目录的 .env 文件加载环境变量（可选），
@@ -45,5 +60,20 @@ func Load() *Config {
 	// RateLimit 配置
 	cfg.RateLimit = loadRateLimit()
 
+	// Search 配置
+	cfg.Search = loadSearch()
+
+	// Trash 配置
+	cfg.Trash = loadTrash()
+
+	// Site 配置
+	cfg.Site = loadSite()
+
+	// Feed 配置
+	cfg.Feed = loadFeed()
+
+	// Sitemap 配置
+	cfg.Sitemap = loadSitemap()
+
 	return cfg
 }
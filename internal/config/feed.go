@@ -0,0 +1,24 @@
+package config
+
+// FeedConfig 定义 RSS/Atom 订阅源相关配置。
+// 对应环境变量：
+//   - FEED_FULL_CONTENT（默认 false）：条目包含渲染后的全文，关闭时只包含摘要
+//   - FEED_LIMIT（默认 20，最大 100）：每个订阅源包含的最新笔记数
+//   - FEED_CACHE_TTL（默认 600）：生成的 XML 在缓存中保留的秒数，笔记发布或更新时会立即失效
+type FeedConfig struct {
+	FullContent     bool
+	Limit           int
+	CacheTTLSeconds int
+}
+
+func loadFeed() FeedConfig {
+	limit := getEnvInt("FEED_LIMIT", 20)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	return FeedConfig{
+		FullContent:     getEnvBool("FEED_FULL_CONTENT", false),
+		Limit:           limit,
+		CacheTTLSeconds: getEnvInt("FEED_CACHE_TTL", 600),
+	}
+}
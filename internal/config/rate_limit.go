@@ -7,15 +7,19 @@ type RateLimitRule struct {
 	WindowSeconds int
 }
 
-// RateLimitConfig 包含登录、图片上传、点赞三类动作的限流配置
+// RateLimitConfig 包含登录、图片上传、点赞、评论、打开分享链接五类动作的限流配置
 // 对应环境变量（秒为单位）：
 // - RL_LOGIN_LIMIT（默认 10） RL_LOGIN_WINDOW（默认 60）
 // - RL_UPLOAD_LIMIT（默认 10） RL_UPLOAD_WINDOW（默认 60）
 // - RL_LIKE_LIMIT（默认 30） RL_LIKE_WINDOW（默认 60）
+// - RL_COMMENT_LIMIT（默认 5） RL_COMMENT_WINDOW（默认 60）
+// - RL_SHARE_LIMIT（默认 30） RL_SHARE_WINDOW（默认 60）
 type RateLimitConfig struct {
 	Login       RateLimitRule
 	UploadImage RateLimitRule
 	Like        RateLimitRule
+	Comment     RateLimitRule
+	Share       RateLimitRule
 }
 
 func loadRateLimit() RateLimitConfig {
@@ -32,5 +36,13 @@ func loadRateLimit() RateLimitConfig {
 			Limit:         int64(getEnvInt("RL_LIKE_LIMIT", 30)),
 			WindowSeconds: getEnvInt("RL_LIKE_WINDOW", 60),
 		},
+		Comment: RateLimitRule{
+			Limit:         int64(getEnvInt("RL_COMMENT_LIMIT", 5)),
+			WindowSeconds: getEnvInt("RL_COMMENT_WINDOW", 60),
+		},
+		Share: RateLimitRule{
+			Limit:         int64(getEnvInt("RL_SHARE_LIMIT", 30)),
+			WindowSeconds: getEnvInt("RL_SHARE_WINDOW", 60),
+		},
 	}
 }
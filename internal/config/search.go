@@ -0,0 +1,18 @@
+package config
+
+// SearchConfig 定义全文检索相关配置。
+// 对应环境变量：
+//   - SEARCH_TS_CONFIG（默认 simple）：PostgreSQL 文本检索配置名，例如 simple、english，或 zhparser/pg_jieba 等扩展提供的中文配置
+//   - SEARCH_CJK_SEGMENT（默认 true）：是否在建立索引与查询前把中日韩文本按字切分（unigram），
+//     适用于 simple 配置下的中文内容；使用中文分词扩展时应关闭
+type SearchConfig struct {
+	TSConfig   string
+	CJKSegment bool
+}
+
+func loadSearch() SearchConfig {
+	return SearchConfig{
+		TSConfig:   getEnv("SEARCH_TS_CONFIG", "simple"),
+		CJKSegment: getEnvBool("SEARCH_CJK_SEGMENT", true),
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import "strings"
+
+// SiteConfig 定义站点对外展示的信息，用于生成订阅源、站点地图等需要绝对地址的内容。
+// 对应环境变量：
+//   - SITE_URL（默认 http://localhost:8080）：站点根地址，末尾的 / 会被去掉
+//   - SITE_TITLE（默认 HYH-Blog）/ SITE_DESCRIPTION：站点标题与描述
+//   - SITE_NOTE_PATH（默认 /api/v1/users/{username}/notes/{slug}）：笔记页面的路径模板，
+//     支持 {id}、{username} 与 {slug} 占位符；有前端页面时应改为前端的路由
+//   - SITE_USER_PATH（默认 /api/v1/public/notes?author_id={id}）：作者页面的路径模板，支持 {id} 与 {username}
+//   - SITE_TAG_PATH（默认 /api/v1/public/notes?tag={tag}）：标签页面的路径模板，支持 {tag}
+type SiteConfig struct {
+	URL         string
+	Title       string
+	Description string
+	NotePath    string
+	UserPath    string
+	TagPath     string
+}
+
+func loadSite() SiteConfig {
+	return SiteConfig{
+		URL:         strings.TrimRight(getEnv("SITE_URL", "http://localhost:8080"), "/"),
+		Title:       getEnv("SITE_TITLE", "HYH-Blog"),
+		Description: getEnv("SITE_DESCRIPTION", "HYH-Blog 公开笔记"),
+		NotePath:    getEnv("SITE_NOTE_PATH", "/api/v1/users/{username}/notes/{slug}"),
+		UserPath:    getEnv("SITE_USER_PATH", "/api/v1/public/notes?author_id={id}"),
+		TagPath:     getEnv("SITE_TAG_PATH", "/api/v1/public/notes?tag={tag}"),
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// SitemapConfig 定义站点地图与 robots.txt 相关配置。
+// 对应环境变量：
+//   - SITEMAP_CACHE_TTL（默认 3600）：生成的站点地图在缓存中保留的秒数，公开笔记变化时会立即失效
+//   - ROBOTS_DISALLOW（默认 /swagger/）：逗号分隔的禁止抓取路径，为空表示允许抓取全部路径
+//   - ROBOTS_FILE（可选）：robots.txt 文件路径，设置后原样返回该文件内容，ROBOTS_DISALLOW 不再生效
+type SitemapConfig struct {
+	CacheTTLSeconds int
+	RobotsDisallow  []string
+	// RobotsTxt 为 ROBOTS_FILE 的内容，未设置或读取失败时为空
+	RobotsTxt string
+}
+
+func loadSitemap() SitemapConfig {
+	var disallow []string
+	for _, p := range strings.Split(getEnv("ROBOTS_DISALLOW", "/swagger/"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			disallow = append(disallow, p)
+		}
+	}
+	var robots string
+	if path := getEnv("ROBOTS_FILE", ""); path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			robots = string(data)
+		}
+	}
+	return SitemapConfig{
+		CacheTTLSeconds: getEnvInt("SITEMAP_CACHE_TTL", 3600),
+		RobotsDisallow:  disallow,
+		RobotsTxt:       robots,
+	}
+}
@@ -0,0 +1,14 @@
+package config
+
+// TrashConfig 定义回收站相关配置。
+// 对应环境变量：
+//   - TRASH_RETENTION_DAYS（默认 30）：已删除笔记在回收站中保留的天数，超过后由后台任务彻底删除；0 表示不自动清理
+type TrashConfig struct {
+	RetentionDays int
+}
+
+func loadTrash() TrashConfig {
+	return TrashConfig{
+		RetentionDays: getEnvInt("TRASH_RETENTION_DAYS", 30),
+	}
+}
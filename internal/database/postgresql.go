@@ -62,6 +62,15 @@ func NewDB(cfg *config.Config) (*DB, error) {
 			&models.Note{},
 			&models.Tag{},
 			&models.Image{},
+			&models.NoteRevision{},
+			&models.NoteSlugHistory{},
+			&models.Comment{},
+			&models.NoteLike{},
+			&models.Bookmark{},
+			&models.Series{},
+			&models.SeriesNote{},
+			&models.NoteLink{},
+			&models.NoteShare{},
 		); err != nil {
 			return nil, fmt.Errorf("failed to migrate database: %w", err)
 		}
@@ -0,0 +1,208 @@
+// Package feed 把公开笔记生成为 RSS 2.0 与 Atom 1.0 订阅源。
+package feed
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+)
+
+// 订阅源格式
+const (
+	FormatRSS  = "rss"
+	FormatAtom = "atom"
+)
+
+// Channel 订阅源本身的信息，Link 为站点（或作者、标签页面）地址，Self 为订阅源自身的地址。
+type Channel struct {
+	Title       string
+	Description string
+	Link        string
+	Self        string
+	Updated     time.Time
+}
+
+// Item 订阅源中的一篇笔记；Content 为 HTML（全文或摘要，由调用方决定）。
+type Item struct {
+	ID         uint
+	Title      string
+	Link       string
+	Author     string
+	Categories []string
+	Content    string
+	Published  time.Time
+	Updated    time.Time
+}
+
+// ContentType 返回格式对应的响应 Content-Type。
+func ContentType(format string) string {
+	if format == FormatAtom {
+		return "application/atom+xml; charset=utf-8"
+	}
+	return "application/rss+xml; charset=utf-8"
+}
+
+// Build 生成指定格式的订阅源 XML（含 XML 声明），format 不是 atom 时生成 RSS。
+func Build(format string, ch Channel, items []Item) ([]byte, error) {
+	var v interface{}
+	if format == FormatAtom {
+		v = atomFeed(ch, items)
+	} else {
+		v = rssFeed(ch, items)
+	}
+	out, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	DCNS    string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	AtomLink      rssLink   `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Creator     string   `xml:"dc:creator,omitempty"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// rssDate RSS 使用 RFC 1123 格式的日期
+func rssDate(t time.Time) string {
+	return t.UTC().Format(http.TimeFormat)
+}
+
+func rssFeed(ch Channel, items []Item) rss {
+	c := rssChannel{
+		Title:       ch.Title,
+		Link:        ch.Link,
+		Description: ch.Description,
+		AtomLink:    rssLink{Href: ch.Self, Rel: "self", Type: "application/rss+xml"},
+		Items:       make([]rssItem, 0, len(items)),
+	}
+	if !ch.Updated.IsZero() {
+		c.LastBuildDate = rssDate(ch.Updated)
+	}
+	for _, it := range items {
+		c.Items = append(c.Items, rssItem{
+			Title:       it.Title,
+			Link:        it.Link,
+			GUID:        rssGUID{IsPermaLink: true, Value: it.Link},
+			PubDate:     rssDate(it.Published),
+			Creator:     it.Author,
+			Categories:  it.Categories,
+			Description: it.Content,
+		})
+	}
+	return rss{Version: "2.0", AtomNS: "http://www.w3.org/2005/Atom", DCNS: "http://purl.org/dc/elements/1.1/", Channel: c}
+}
+
+type atom struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     *atomAuthor    `xml:"author,omitempty"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// atomDate Atom 使用 RFC 3339 格式的日期
+func atomDate(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func atomFeed(ch Channel, items []Item) atom {
+	updated := ch.Updated
+	if updated.IsZero() {
+		// Atom 要求 updated 必填，没有条目时使用 Unix 零点，保证同一内容输出稳定
+		updated = time.Unix(0, 0)
+	}
+	f := atom{
+		Title:    ch.Title,
+		Subtitle: ch.Description,
+		ID:       ch.Self,
+		Updated:  atomDate(updated),
+		Links: []atomLink{
+			{Href: ch.Link, Rel: "alternate", Type: "text/html"},
+			{Href: ch.Self, Rel: "self", Type: "application/atom+xml"},
+		},
+		Entries: make([]atomEntry, 0, len(items)),
+	}
+	for _, it := range items {
+		e := atomEntry{
+			Title:     it.Title,
+			ID:        it.Link,
+			Link:      atomLink{Href: it.Link, Rel: "alternate", Type: "text/html"},
+			Published: atomDate(it.Published),
+			Updated:   atomDate(it.Updated),
+			Content:   atomContent{Type: "html", Value: it.Content},
+		}
+		if it.Author != "" {
+			e.Author = &atomAuthor{Name: it.Author}
+		}
+		for _, c := range it.Categories {
+			e.Categories = append(e.Categories, atomCategory{Term: c})
+		}
+		f.Entries = append(f.Entries, e)
+	}
+	return f
+}
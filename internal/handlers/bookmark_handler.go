@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	"HYH-Blog-Gin/internal/services"
+	"HYH-Blog-Gin/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BookmarkHandler 处理收藏（阅读列表）相关请求。
+type BookmarkHandler struct {
+	svc services.BookmarkService
+}
+
+// NewBookmarkHandler 创建 BookmarkHandler 实例。
+func NewBookmarkHandler(svc services.BookmarkService) *BookmarkHandler {
+	return &BookmarkHandler{svc: svc}
+}
+
+// BookmarkRequest 收藏请求体，字段均可选；省略的字段在更新已有收藏时保持不变。
+type BookmarkRequest struct {
+	Folder     *string `json:"folder" example:"to-read"`
+	Annotation *string `json:"annotation" example:"Revisit the caching section"`
+}
+
+// BookmarkState 取消收藏的结果
+type BookmarkState struct {
+	Bookmarked bool `json:"bookmarked" example:"false"`
+	Changed    bool `json:"changed" example:"true"`
+}
+
+// writeBookmarkError 将 service 错误映射为 HTTP 响应。
+func writeBookmarkError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		utils.NotFound(c, "note not found")
+	case errors.Is(err, services.ErrForbidden):
+		utils.Forbidden(c, "forbidden")
+	case errors.Is(err, services.ErrInvalidFolder), errors.Is(err, services.ErrInvalidAnnotation):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalError(c, err.Error())
+	}
+}
+
+// Add 收藏笔记
+// @Summary 收藏笔记
+// @Description 收藏可见的笔记，可指定收藏夹与私人批注；已收藏时更新提供的字段（需要鉴权）
+// @Tags 收藏
+// @Accept json
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Param payload body BookmarkRequest false "收藏夹与批注"
+// @Security BearerAuth
+// @Success 200 {object} BookmarkSwagger
+// @Success 201 {object} BookmarkSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/bookmark [post]
+func (h *BookmarkHandler) Add(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	noteID, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	// 请求体可省略
+	var req BookmarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BadRequest(c, err.Error())
+		return
+	}
+	bookmark, created, err := h.svc.Add(userID, noteID, services.BookmarkInput{Folder: req.Folder, Annotation: req.Annotation})
+	if err != nil {
+		writeBookmarkError(c, err)
+		return
+	}
+	if created {
+		utils.Created(c, bookmark)
+		return
+	}
+	utils.OK(c, bookmark)
+}
+
+// Remove 取消收藏
+// @Summary 取消收藏
+// @Description 取消对指定笔记的收藏，未收藏时幂等（changed=false）（需要鉴权）
+// @Tags 收藏
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Security BearerAuth
+// @Success 200 {object} BookmarkState
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/bookmark [delete]
+func (h *BookmarkHandler) Remove(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	noteID, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	changed, err := h.svc.Remove(userID, noteID)
+	if err != nil {
+		writeBookmarkError(c, err)
+		return
+	}
+	utils.OK(c, BookmarkState{Bookmarked: false, Changed: changed})
+}
+
+// List 收藏列表
+// @Summary 收藏列表
+// @Description 按收藏时间倒序分页列出当前用户的收藏（附带笔记），可按收藏夹过滤；folder 传空字符串表示未分类（需要鉴权）
+// @Tags 收藏
+// @Produce json
+// @Param folder query string false "收藏夹名称"
+// @Param page query int false "页码"
+// @Param limit query int false "每页数量"
+// @Security BearerAuth
+// @Success 200 {array} BookmarkSwagger
+// @Router /api/v1/bookmarks [get]
+func (h *BookmarkHandler) List(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	var folder *string
+	if f, ok := c.GetQuery("folder"); ok {
+		folder = &f
+	}
+	bookmarks, total, err := h.svc.List(userID, folder, page, limit)
+	if err != nil {
+		writeBookmarkError(c, err)
+		return
+	}
+	utils.Paginated(c, bookmarks, page, limit, total)
+}
+
+// Folders 收藏夹列表
+// @Summary 收藏夹列表
+// @Description 列出当前用户的收藏夹及其中的收藏数量，name 为空表示未分类（需要鉴权）
+// @Tags 收藏
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} BookmarkFolderSwagger
+// @Router /api/v1/bookmarks/folders [get]
+func (h *BookmarkHandler) Folders(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	folders, err := h.svc.Folders(userID)
+	if err != nil {
+		writeBookmarkError(c, err)
+		return
+	}
+	utils.OK(c, folders)
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"HYH-Blog-Gin/internal/services"
+	"HYH-Blog-Gin/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CommentHandler 处理笔记评论相关请求。
+type CommentHandler struct {
+	svc services.CommentService
+}
+
+// NewCommentHandler 创建 CommentHandler 实例。
+func NewCommentHandler(svc services.CommentService) *CommentHandler {
+	return &CommentHandler{svc: svc}
+}
+
+// CommentCreateRequest 发表评论请求体，parent_id 为空表示顶层评论。
+type CommentCreateRequest struct {
+	Content  string `json:"content" binding:"required"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+// CommentUpdateRequest 编辑评论请求体
+type CommentUpdateRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+// writeCommentError 将 service 错误映射为 HTTP 响应。
+func writeCommentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		utils.NotFound(c, "note or comment not found")
+	case errors.Is(err, services.ErrForbidden):
+		utils.Forbidden(c, "forbidden")
+	case errors.Is(err, services.ErrCommentsClosed):
+		utils.Forbidden(c, err.Error())
+	case errors.Is(err, services.ErrInvalidComment), errors.Is(err, services.ErrInvalidParent):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalError(c, err.Error())
+	}
+}
+
+// List 列出笔记的评论
+// @Summary 评论列表
+// @Description 按创建时间正序分页列出笔记的顶层评论，每条附带嵌套的全部回复；已删除的评论内容为空（可选鉴权）
+// @Tags 评论
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Param page query int false "页码"
+// @Param limit query int false "每页顶层评论数量"
+// @Security BearerAuth
+// @Success 200 {array} CommentSwagger
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/comments [get]
+func (h *CommentHandler) List(c *gin.Context) {
+	// 可选鉴权：未登录时 userID 为 0，只能读取公开笔记的评论
+	userID, _ := utils.GetUserIDFromContext(c)
+	noteID, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	threads, total, err := h.svc.List(userID, noteID, page, limit)
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+	utils.Paginated(c, threads, page, limit, total)
+}
+
+// Create 发表评论或回复
+// @Summary 发表评论
+// @Description 在公开笔记下发表评论，指定 parent_id 时回复该评论（需要鉴权，按用户限流）
+// @Tags 评论
+// @Accept json
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Param payload body CommentCreateRequest true "评论内容"
+// @Security BearerAuth
+// @Success 201 {object} CommentSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/comments [post]
+func (h *CommentHandler) Create(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	noteID, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	var req CommentCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, err.Error())
+		return
+	}
+	comment, err := h.svc.Create(userID, noteID, req.ParentID, req.Content)
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+	utils.Created(c, comment)
+}
+
+// Update 编辑评论
+// @Summary 编辑评论
+// @Description 仅评论作者可编辑，已删除的评论不可编辑（需要鉴权）
+// @Tags 评论
+// @Accept json
+// @Produce json
+// @Param id path int true "评论 ID"
+// @Param payload body CommentUpdateRequest true "新的评论内容"
+// @Security BearerAuth
+// @Success 200 {object} CommentSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/comments/{id} [put]
+func (h *CommentHandler) Update(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	var req CommentUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, err.Error())
+		return
+	}
+	comment, err := h.svc.Update(userID, id, req.Content)
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+	utils.OK(c, comment)
+}
+
+// Delete 删除评论
+// @Summary 删除评论
+// @Description 软删除评论：评论作者或笔记作者可删除，回复结构保留（需要鉴权）
+// @Tags 评论
+// @Param id path int true "评论 ID"
+// @Security BearerAuth
+// @Success 200 {object} SimpleMessage
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/comments/{id} [delete]
+func (h *CommentHandler) Delete(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	if err := h.svc.Delete(userID, id); err != nil {
+		writeCommentError(c, err)
+		return
+	}
+	utils.OKMsg(c, "comment deleted successfully", nil)
+}
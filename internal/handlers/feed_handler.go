@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"HYH-Blog-Gin/internal/cache"
+	"HYH-Blog-Gin/internal/feed"
+	"HYH-Blog-Gin/internal/services"
+	"HYH-Blog-Gin/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedHandler 提供 RSS/Atom 订阅源。生成的 XML 按订阅源缓存代数缓存在 cache 中，
+// 任一笔记发布、更新或下线时代数递增，旧缓存随之失效。
+type FeedHandler struct {
+	svc   services.FeedService
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewFeedHandler 创建 FeedHandler 实例，ttl<=0 表示不缓存。
+func NewFeedHandler(svc services.FeedService, c cache.Cache, ttl time.Duration) *FeedHandler {
+	if c == nil {
+		c = cache.NewNoOpCache()
+	}
+	return &FeedHandler{svc: svc, cache: c, ttl: ttl}
+}
+
+// SiteRSS 全站 RSS
+// @Summary 全站 RSS 订阅源
+// @Description 最新的公开笔记（RSS 2.0），支持 ETag/If-None-Match 与 Last-Modified/If-Modified-Since 条件请求
+// @Tags 订阅
+// @Produce xml
+// @Success 200 {string} string "RSS XML"
+// @Success 304 {string} string "未修改"
+// @Router /feed.xml [get]
+func (h *FeedHandler) SiteRSS(c *gin.Context) { h.serve(c, feed.FormatRSS, services.FeedScopeSite, "") }
+
+// SiteAtom 全站 Atom
+// @Summary 全站 Atom 订阅源
+// @Description 最新的公开笔记（Atom 1.0），条件请求同 RSS
+// @Tags 订阅
+// @Produce xml
+// @Success 200 {string} string "Atom XML"
+// @Success 304 {string} string "未修改"
+// @Router /atom.xml [get]
+func (h *FeedHandler) SiteAtom(c *gin.Context) {
+	h.serve(c, feed.FormatAtom, services.FeedScopeSite, "")
+}
+
+// UserRSS 作者 RSS
+// @Summary 作者 RSS 订阅源
+// @Description 指定作者最新的公开笔记（RSS 2.0）；/users/{username}/atom.xml 提供 Atom 格式
+// @Tags 订阅
+// @Produce xml
+// @Param username path string true "用户名"
+// @Success 200 {string} string "RSS XML"
+// @Success 304 {string} string "未修改"
+// @Failure 404 {object} map[string]interface{}
+// @Router /users/{username}/feed.xml [get]
+func (h *FeedHandler) UserRSS(c *gin.Context) {
+	h.serve(c, feed.FormatRSS, services.FeedScopeUser, c.Param("username"))
+}
+
+// UserAtom 作者 Atom
+func (h *FeedHandler) UserAtom(c *gin.Context) {
+	h.serve(c, feed.FormatAtom, services.FeedScopeUser, c.Param("username"))
+}
+
+// TagRSS 标签 RSS
+// @Summary 标签 RSS 订阅源
+// @Description 带有指定标签的最新公开笔记（RSS 2.0）；/tags/{name}/atom.xml 提供 Atom 格式
+// @Tags 订阅
+// @Produce xml
+// @Param name path string true "标签名"
+// @Success 200 {string} string "RSS XML"
+// @Success 304 {string} string "未修改"
+// @Router /tags/{name}/feed.xml [get]
+func (h *FeedHandler) TagRSS(c *gin.Context) {
+	h.serve(c, feed.FormatRSS, services.FeedScopeTag, c.Param("name"))
+}
+
+// TagAtom 标签 Atom
+func (h *FeedHandler) TagAtom(c *gin.Context) {
+	h.serve(c, feed.FormatAtom, services.FeedScopeTag, c.Param("name"))
+}
+
+// serve 优先读取缓存，未命中时生成并写入缓存，然后按条件请求头返回 304 或 XML。
+func (h *FeedHandler) serve(c *gin.Context, format, scope, value string) {
+	ctx := c.Request.Context()
+	gen, _ := h.cache.GetInteger(ctx, cache.PublicGenerationKey)
+	key := cache.NewKeyGenerator().Feed(gen, format, scope, value)
+
+	var doc services.FeedDocument
+	if hit, err := h.cache.Get(ctx, key, &doc); err != nil || !hit {
+		d, err := h.svc.Feed(format, scope, value)
+		if err != nil {
+			if errors.Is(err, services.ErrNotFound) {
+				utils.NotFound(c, "user not found")
+				return
+			}
+			utils.InternalError(c, err.Error())
+			return
+		}
+		doc = *d
+		if h.ttl > 0 {
+			_ = h.cache.Set(ctx, key, doc, h.ttl)
+		}
+	}
+
+	c.Header("ETag", doc.ETag)
+	c.Header("Cache-Control", "public, no-cache")
+	if !doc.LastModified.IsZero() {
+		c.Header("Last-Modified", doc.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if feedNotModified(c.Request, &doc) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, feed.ContentType(format), []byte(doc.XML))
+}
+
+// feedNotModified 判断条件请求是否命中：If-None-Match 优先（支持多个值、* 与弱校验前缀），
+// 其次比较 If-Modified-Since 与最后修改时间（精确到秒）。
+func feedNotModified(r *http.Request, doc *services.FeedDocument) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == doc.ETag {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !doc.LastModified.IsZero() {
+		if t, err := http.ParseTime(ims); err == nil {
+			return !doc.LastModified.Truncate(time.Second).After(t)
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"HYH-Blog-Gin/internal/services"
+	"HYH-Blog-Gin/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoteExportHandler 处理导出笔记为 Markdown 压缩包的请求。
+type NoteExportHandler struct {
+	svc services.NoteExportService
+}
+
+// NewNoteExportHandler 创建 NoteExportHandler 实例。
+func NewNoteExportHandler(svc services.NoteExportService) *NoteExportHandler {
+	return &NoteExportHandler{svc: svc}
+}
+
+// Export 导出全部笔记
+// @Summary 导出笔记
+// @Description 以 zip 流式下载当前用户的全部笔记（不含回收站），每篇笔记一个 Markdown 文件，YAML 前置元数据包含标题、标签、可见性、时间、阅读量与点赞数。
+// @Description images=true 时同时打包引用的本地图片，并把正文与封面中的地址改写为相对路径（需要鉴权）
+// @Tags 笔记
+// @Produce application/zip
+// @Param images query bool false "是否打包引用的本地图片"
+// @Security BearerAuth
+// @Success 200 {file} file "zip 压缩包"
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/notes/export [get]
+func (h *NoteExportHandler) Export(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	withImages, _ := strconv.ParseBool(c.DefaultQuery("images", "false"))
+
+	filename := "notes-" + time.Now().Format("20060102") + ".zip"
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+	if err := h.svc.Export(userID, c.Writer, withImages); err != nil {
+		log.Printf("导出笔记失败: %v", err)
+		// 尚未写出任何内容时仍可返回 JSON 错误；已开始传输时只能中断，客户端会得到不完整的压缩包
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			utils.InternalError(c, err.Error())
+			return
+		}
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
@@ -3,11 +3,16 @@ package handlers
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"HYH-Blog-Gin/internal/cache"
+	"HYH-Blog-Gin/internal/models"
 	"HYH-Blog-Gin/internal/services"
+	"HYH-Blog-Gin/internal/trending"
 	"HYH-Blog-Gin/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -20,29 +25,80 @@ type SimpleMessage struct {
 
 // NoteHandler 处理笔记相关的请求，封装了笔记业务服务依赖。
 type NoteHandler struct {
-	svc   services.NoteService
-	cache cache.Cache
+	svc      services.NoteService
+	cache    cache.Cache
+	trending services.TrendingService
 }
 
 // NoteCreateRequest 表示创建笔记的请求体。
+// summary 为空时由正文自动生成；cover_image 须为已上传图片的 URL。
+// status 可选 draft/scheduled/published/unpublished；未指定时由 public 与 publish_at 推导，默认草稿。
+// visibility 可选 public/unlisted/private：unlisted 表示发布后凭链接可读，但不出现在公开列表、订阅源与站点地图中。
 type NoteCreateRequest struct {
-	Title   string   `json:"title" binding:"required"`
-	Content string   `json:"content" binding:"required"`
-	Tags    []string `json:"tags"`
-	Public  *bool    `json:"public"`
+	Title      string     `json:"title" binding:"required"`
+	Content    string     `json:"content" binding:"required"`
+	Summary    string     `json:"summary"`
+	CoverImage string     `json:"cover_image"`
+	Tags       []string   `json:"tags"`
+	Public     *bool      `json:"public"`
+	Visibility *string    `json:"visibility" example:"unlisted"`
+	Status     *string    `json:"status"`
+	PublishAt  *time.Time `json:"publish_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
 }
 
 // NoteUpdateRequest 表示更新笔记的请求体（字段均为可选）。
+// summary 传空字符串表示改为自动生成，cover_image 传空字符串表示清除封面。
 type NoteUpdateRequest struct {
-	Title   *string  `json:"title"`
-	Content *string  `json:"content"`
-	Tags    []string `json:"tags"`
-	Public  *bool    `json:"public"`
+	Title      *string    `json:"title"`
+	Content    *string    `json:"content"`
+	Summary    *string    `json:"summary"`
+	CoverImage *string    `json:"cover_image"`
+	Tags       []string   `json:"tags"`
+	Public     *bool      `json:"public"`
+	Visibility *string    `json:"visibility" example:"unlisted"`
+	Status     *string    `json:"status"`
+	PublishAt  *time.Time `json:"publish_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	// Version 读取笔记时得到的版本号；也可以改用 If-Match 请求头传递 ETag
+	Version *int64 `json:"version" example:"3"`
 }
 
-// NewNoteHandler 创建并返回 NoteHandler 实例（使用 service 层）。
-func NewNoteHandler(svc services.NoteService, c cache.Cache) *NoteHandler {
-	return &NoteHandler{svc: svc, cache: c}
+// NoteBulkRequest 表示批量操作笔记的请求体。
+// action 可选 delete/restore/set_visibility/add_tags/remove_tags/replace_tags；
+// set_visibility 需提供 public，标签相关操作需提供 tags（replace_tags 传空数组表示清空标签）。
+type NoteBulkRequest struct {
+	IDs    []uint   `json:"ids" binding:"required" example:"1,2,3"`
+	Action string   `json:"action" binding:"required" example:"add_tags"`
+	Public *bool    `json:"public"`
+	Tags   []string `json:"tags" example:"go,gin"`
+}
+
+// NoteBulkResponse 表示批量操作的结果：results 与请求中的 ids（去重后）一一对应。
+type NoteBulkResponse struct {
+	Succeeded int                     `json:"succeeded" example:"2"`
+	Failed    int                     `json:"failed" example:"1"`
+	Results   []models.NoteBulkResult `json:"results"`
+}
+
+// noteETag 根据笔记版本号生成 ETag。
+func noteETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// parseETag 解析 If-Match 中的 ETag（兼容弱校验前缀 W/），返回其中的版本号。
+func parseETag(s string) (int64, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "W/")
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(s[1:len(s)-1], 10, 64)
+	return v, err == nil && v > 0
+}
+
+// NewNoteHandler 创建并返回 NoteHandler 实例（使用 service 层），trending 为 nil 时不记录热度。
+func NewNoteHandler(svc services.NoteService, c cache.Cache, trending services.TrendingService) *NoteHandler {
+	return &NoteHandler{svc: svc, cache: c, trending: trending}
 }
 
 // GetNotes 获取当前用户的笔记列表
@@ -81,7 +137,7 @@ func (h *NoteHandler) GetNotes(c *gin.Context) {
 
 // CreateNote 创建新笔记
 // @Summary 创建笔记
-// @Description 创建笔记并可同时处理标签（需要鉴权）
+// @Description 创建笔记并可同时处理标签；默认为草稿，可直接发布或通过 publish_at 定时发布（需要鉴权）
 // @Tags 笔记
 // @Accept json
 // @Produce json
@@ -101,7 +157,19 @@ func (h *NoteHandler) CreateNote(c *gin.Context) {
 		utils.BadRequest(c, err.Error())
 		return
 	}
-	note, err := h.svc.CreateNote(userID, strings.TrimSpace(req.Title), req.Content, req.Tags, req.Public)
+	title := strings.TrimSpace(req.Title)
+	note, err := h.svc.CreateNote(userID, services.NoteInput{
+		Title:      &title,
+		Content:    &req.Content,
+		Summary:    &req.Summary,
+		CoverImage: &req.CoverImage,
+		Tags:       req.Tags,
+		IsPublic:   req.Public,
+		Visibility: req.Visibility,
+		Status:     req.Status,
+		PublishAt:  req.PublishAt,
+		ExpiresAt:  req.ExpiresAt,
+	})
 	if err != nil {
 		utils.BadRequest(c, err.Error())
 		return
@@ -123,22 +191,20 @@ func parseUintParam(s string) (uint, bool) {
 
 // GetNote 获取单个笔记
 // @Summary 获取笔记
-// @Description 根据 ID 获取单条笔记；如果笔记非公开且非作者则返回 403（需要鉴权）
+// @Description 根据 ID 获取单条笔记；匿名可读公开笔记，如果笔记非公开且非作者则返回 403（可选鉴权）
 // @Tags 笔记
 // @Produce json
 // @Param id path int true "笔记 ID"
 // @Security BearerAuth
 // @Success 200 {object} NoteSwagger "example: {\"id\":1,\"title\":\"hello\"}"
 // @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
 // @Failure 403 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Router /api/v1/notes/{id} [get]
 func (h *NoteHandler) GetNote(c *gin.Context) {
-	userID, ok := utils.GetUserIDFromContext(c)
-	if !ok {
-		utils.Unauthorized(c, "unauthorized")
-		return
-	}
+	// 可选鉴权：未登录时 userID 为 0，只能读取公开笔记
+	userID, _ := utils.GetUserIDFromContext(c)
 	idStr := c.Param("id")
 	id, ok := parseUintParam(idStr)
 	if !ok {
@@ -159,46 +225,127 @@ func (h *NoteHandler) GetNote(c *gin.Context) {
 		return
 	}
 
-	// 异步增加阅读量（高频写，先写 Redis，再由后台同步至 DB）
-	if h.cache != nil {
-		go func(id uint) {
-			_, err := h.cache.Increment(context.Background(), cache.NewKeyGenerator().NoteViews(id), 1)
-			if err != nil {
-				return
-			}
-		}(id)
-	}
+	h.incrementViews(id)
 
+	c.Header("ETag", noteETag(note.Version))
 	utils.OK(c, note)
 }
 
-// LikeNote 点赞接口（示例）
+// GetRelatedNotes 相关阅读
+// @Summary 相关笔记推荐
+// @Description 按标签重合度（Jaccard）、新近度与热度（阅读量、点赞数）推荐与该笔记相关的其他公开笔记；笔记可见性与获取笔记一致（可选鉴权）
+// @Tags 笔记
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Param limit query int false "返回数量，默认 5，最大 20"
+// @Security BearerAuth
+// @Success 200 {array} RelatedNoteSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/related [get]
+func (h *NoteHandler) GetRelatedNotes(c *gin.Context) {
+	userID, _ := utils.GetUserIDFromContext(c)
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if limit <= 0 || limit > services.MaxRelatedNotes {
+		limit = 5
+	}
+	related, err := h.svc.RelatedNotes(userID, id, limit)
+	if err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			utils.NotFound(c, "note not found")
+			return
+		}
+		if errors.Is(err, services.ErrForbidden) {
+			utils.Forbidden(c, "forbidden")
+			return
+		}
+		utils.InternalError(c, err.Error())
+		return
+	}
+	utils.OK(c, related)
+}
+
+// incrementViews 异步增加阅读量（高频写，先写 Redis，再由后台同步至 DB），同时计入热度排行
+func (h *NoteHandler) incrementViews(id uint) {
+	if h.trending != nil {
+		go h.trending.RecordView(id)
+	}
+	if h.cache == nil {
+		return
+	}
+	go func(id uint) {
+		_, err := h.cache.Increment(context.Background(), cache.NewKeyGenerator().NoteViews(id), 1)
+		if err != nil {
+			return
+		}
+	}(id)
+}
+
+// LikeState 点赞/取消点赞的结果
+type LikeState struct {
+	Liked   bool `json:"liked" example:"true"`
+	Changed bool `json:"changed" example:"true"`
+}
+
+// LikeNote 点赞接口
 // @Summary 给笔记点赞
-// @Description 为指定笔记增加一个点赞（高频写，写入 Redis，后台同步到 DB）；需要鉴权
+// @Description 为指定笔记点赞，每个用户对同一笔记只计一次，重复点赞幂等（changed=false）；计数先写 Redis，后台按点赞记录同步到 DB（需要鉴权）
 // @Tags 笔记
-// @Accept json
 // @Produce json
 // @Param id path int true "笔记 ID"
 // @Security BearerAuth
-// @Success 200 {object} SimpleMessage
+// @Success 200 {object} LikeState
 // @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
 // @Router /api/v1/notes/{id}/like [post]
 func (h *NoteHandler) LikeNote(c *gin.Context) {
+	h.setLike(c, true)
+}
+
+// UnlikeNote 取消点赞接口
+// @Summary 取消点赞
+// @Description 取消对指定笔记的点赞，未点赞时幂等（changed=false）（需要鉴权）
+// @Tags 笔记
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Security BearerAuth
+// @Success 200 {object} LikeState
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/like [delete]
+func (h *NoteHandler) UnlikeNote(c *gin.Context) {
+	h.setLike(c, false)
+}
+
+// setLike 点赞或取消点赞；点赞记录实际变化时调整 Redis 中的 likes 计数并标记待同步。
+func (h *NoteHandler) setLike(c *gin.Context, like bool) {
 	userID, ok := utils.GetUserIDFromContext(c)
 	if !ok {
 		utils.Unauthorized(c, "unauthorized")
 		return
 	}
-	_ = userID // currently unused; could be used to prevent duplicate likes per user
-	idStr := c.Param("id")
-	id, ok := parseUintParam(idStr)
+	id, ok := parseUintParam(c.Param("id"))
 	if !ok {
 		utils.BadRequest(c, "invalid id")
 		return
 	}
-	// 点赞前校验笔记是否存在/可见
-	if _, err := h.svc.GetNoteByID(userID, id); err != nil {
+	var changed bool
+	var err error
+	if like {
+		changed, err = h.svc.LikeNote(userID, id)
+	} else {
+		changed, err = h.svc.UnlikeNote(userID, id)
+	}
+	if err != nil {
 		if errors.Is(err, services.ErrNotFound) {
 			utils.NotFound(c, "note not found")
 			return
@@ -210,29 +357,39 @@ func (h *NoteHandler) LikeNote(c *gin.Context) {
 		utils.InternalError(c, err.Error())
 		return
 	}
-	// 增加 likes 计数（仅写 Redis）
-	if h.cache != nil {
-		if _, err := h.cache.Increment(context.Background(), cache.NewKeyGenerator().NoteLikes(id), 1); err != nil {
-			utils.InternalError(c, "failed to increment like")
-			return
+	if changed {
+		delta := int64(1)
+		if !like {
+			delta = -1
+		}
+		if h.cache != nil {
+			// 计数仅用于标记待同步，失败时下次该笔记点赞变化仍会触发重新统计
+			_, _ = h.cache.Increment(context.Background(), cache.NewKeyGenerator().NoteLikes(id), delta)
+		}
+		if h.trending != nil {
+			h.trending.RecordLike(id, delta)
 		}
 	}
-	utils.OKMsg(c, "liked", nil)
+	utils.OK(c, LikeState{Liked: like, Changed: changed})
 }
 
 // UpdateNote 更新笔记
 // @Summary 更新笔记
-// @Description 仅作者可更新笔记，支持部分字段更新并可替换标签集合（需要鉴权）
+// @Description 仅作者可更新笔记，支持部分字段更新并可替换标签集合；可通过 status/publish_at/expires_at 管理发布状态。
+// @Description 必须通过 If-Match 请求头或 version 字段提供读取时的版本，版本已过期时返回 409 并附带服务端当前的笔记（需要鉴权）
 // @Tags 笔记
 // @Accept json
 // @Produce json
 // @Param id path int true "笔记 ID"
+// @Param If-Match header string false "读取笔记时得到的 ETag（带引号的版本号）"
 // @Param payload body NoteUpdateRequest true "更新内容（字段可选）"
 // @Security BearerAuth
 // @Success 200 {object} NoteSwagger
 // @Failure 400 {object} map[string]interface{}
 // @Failure 403 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} NoteSwagger
+// @Failure 428 {object} map[string]interface{}
 // @Router /api/v1/notes/{id} [put]
 func (h *NoteHandler) UpdateNote(c *gin.Context) {
 	userID, ok := utils.GetUserIDFromContext(c)
@@ -251,7 +408,37 @@ func (h *NoteHandler) UpdateNote(c *gin.Context) {
 		utils.BadRequest(c, err.Error())
 		return
 	}
-	note, err := h.svc.UpdateNote(userID, id, req.Title, req.Content, req.Tags, req.Public)
+	// 前置条件：If-Match 与 body 中的 version 同时提供时必须一致
+	version := req.Version
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		v, ok := parseETag(ifMatch)
+		if !ok {
+			utils.BadRequest(c, "invalid If-Match header")
+			return
+		}
+		if version != nil && *version != v {
+			utils.BadRequest(c, "If-Match and version do not match")
+			return
+		}
+		version = &v
+	}
+	if version == nil {
+		utils.PreconditionRequired(c, "If-Match header or version field is required")
+		return
+	}
+	note, err := h.svc.UpdateNote(userID, id, services.NoteInput{
+		Title:      req.Title,
+		Content:    req.Content,
+		Summary:    req.Summary,
+		CoverImage: req.CoverImage,
+		Tags:       req.Tags,
+		IsPublic:   req.Public,
+		Visibility: req.Visibility,
+		Status:     req.Status,
+		PublishAt:  req.PublishAt,
+		ExpiresAt:  req.ExpiresAt,
+		Version:    version,
+	})
 	if err != nil {
 		if errors.Is(services.ErrNotFound, err) {
 			utils.NotFound(c, "note not found")
@@ -261,15 +448,35 @@ func (h *NoteHandler) UpdateNote(c *gin.Context) {
 			utils.Forbidden(c, "forbidden")
 			return
 		}
+		if errors.Is(err, services.ErrVersionConflict) {
+			h.writeVersionConflict(c, userID, id)
+			return
+		}
+		if errors.Is(err, services.ErrInvalidStatus) || errors.Is(err, services.ErrInvalidVisibility) || errors.Is(err, services.ErrInvalidSchedule) || errors.Is(err, services.ErrInvalidCover) {
+			utils.BadRequest(c, err.Error())
+			return
+		}
 		utils.InternalError(c, err.Error())
 		return
 	}
+	c.Header("ETag", noteETag(note.Version))
 	utils.OK(c, note)
 }
 
+// writeVersionConflict 返回 409，并附带服务端当前的笔记与 ETag，便于客户端合并后重试。
+func (h *NoteHandler) writeVersionConflict(c *gin.Context, userID, id uint) {
+	current, err := h.svc.GetNoteByID(userID, id)
+	if err != nil {
+		utils.Conflict(c, services.ErrVersionConflict.Error())
+		return
+	}
+	c.Header("ETag", noteETag(current.Version))
+	utils.ConflictWithData(c, services.ErrVersionConflict.Error(), current)
+}
+
 // DeleteNote 删除笔记
 // @Summary 删除笔记
-// @Description 仅作者可删除笔记（需要鉴权）
+// @Description 仅作者可删除笔记，删除后进入回收站，可恢复或彻底删除（需要鉴权）
 // @Tags 笔记
 // @Param id path int true "笔记 ID"
 // @Security BearerAuth
@@ -303,3 +510,403 @@ func (h *NoteHandler) DeleteNote(c *gin.Context) {
 	}
 	utils.OKMsg(c, "note deleted successfully", nil)
 }
+
+// BulkNotes 批量操作笔记
+// @Summary 批量操作笔记
+// @Description 在单个事务中对一组笔记（最多 500 篇）执行同一操作：移入回收站、恢复、公开/取消公开、追加/移除/替换标签。
+// @Description 每篇笔记单独校验归属，不存在或不属于当前用户的笔记记为失败，其余笔记照常处理（需要鉴权）
+// @Tags 笔记
+// @Accept json
+// @Produce json
+// @Param payload body NoteBulkRequest true "批量操作"
+// @Security BearerAuth
+// @Success 200 {object} NoteBulkResponse
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/notes/bulk [post]
+func (h *NoteHandler) BulkNotes(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	var req NoteBulkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, err.Error())
+		return
+	}
+	results, err := h.svc.BulkNotes(userID, services.NoteBulkInput{
+		IDs:      req.IDs,
+		Action:   req.Action,
+		IsPublic: req.Public,
+		Tags:     req.Tags,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidBulk) {
+			utils.BadRequest(c, err.Error())
+			return
+		}
+		utils.InternalError(c, err.Error())
+		return
+	}
+	resp := NoteBulkResponse{Results: results}
+	for _, r := range results {
+		if r.OK {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+	utils.OK(c, resp)
+}
+
+// ListTrash 回收站列表
+// @Summary 回收站列表
+// @Description 按删除时间倒序分页列出当前用户已删除的笔记；超过保留期（TRASH_RETENTION_DAYS）的笔记会被自动彻底删除（需要鉴权）
+// @Tags 笔记
+// @Produce json
+// @Param page query int false "页码"
+// @Param limit query int false "每页数量"
+// @Security BearerAuth
+// @Success 200 {array} NoteSwagger
+// @Router /api/v1/notes/trash [get]
+func (h *NoteHandler) ListTrash(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	notes, total, err := h.svc.ListTrash(userID, page, limit)
+	if err != nil {
+		utils.InternalError(c, err.Error())
+		return
+	}
+	utils.Paginated(c, notes, page, limit, total)
+}
+
+// RestoreNote 从回收站恢复笔记
+// @Summary 恢复笔记
+// @Description 将回收站中的笔记恢复为未删除状态，删除时移除的收藏与系列关系不会恢复（需要鉴权，仅作者）
+// @Tags 笔记
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Security BearerAuth
+// @Success 200 {object} NoteSwagger
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/restore [post]
+func (h *NoteHandler) RestoreNote(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	note, err := h.svc.RestoreNote(userID, id)
+	if err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			utils.NotFound(c, "note not found in trash")
+			return
+		}
+		if errors.Is(err, services.ErrForbidden) {
+			utils.Forbidden(c, "forbidden")
+			return
+		}
+		utils.InternalError(c, err.Error())
+		return
+	}
+	c.Header("ETag", noteETag(note.Version))
+	utils.OK(c, note)
+}
+
+// PurgeNote 彻底删除笔记
+// @Summary 彻底删除笔记
+// @Description 彻底删除回收站中的笔记及其标签关联、修订历史、评论等数据，不可恢复（需要鉴权，仅作者）
+// @Tags 笔记
+// @Param id path int true "笔记 ID"
+// @Security BearerAuth
+// @Success 200 {object} SimpleMessage
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/purge [delete]
+func (h *NoteHandler) PurgeNote(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	if err := h.svc.PurgeNote(userID, id); err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			utils.NotFound(c, "note not found in trash")
+			return
+		}
+		if errors.Is(err, services.ErrForbidden) {
+			utils.Forbidden(c, "forbidden")
+			return
+		}
+		utils.InternalError(c, err.Error())
+		return
+	}
+	utils.OKMsg(c, "note purged successfully", nil)
+}
+
+// GetPublicNotes 获取公开笔记列表
+// @Summary 公开笔记列表
+// @Description 分页获取所有公开笔记，按创建时间倒序，可按作者与标签过滤（无需鉴权）
+// @Tags 公开
+// @Produce json
+// @Param page query int false "页码"
+// @Param limit query int false "每页数量"
+// @Param author_id query int false "作者 ID"
+// @Param tag query string false "标签名"
+// @Success 200 {array} NoteSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/public/notes [get]
+func (h *NoteHandler) GetPublicNotes(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	var filter models.PublicNoteFilter
+	if s := c.Query("author_id"); s != "" {
+		authorID, ok := parseUintParam(s)
+		if !ok {
+			utils.BadRequest(c, "invalid author_id")
+			return
+		}
+		filter.AuthorID = authorID
+	}
+	filter.Tag = strings.TrimSpace(c.Query("tag"))
+
+	notes, total, err := h.svc.GetPublicNotes(filter, page, limit)
+	if err != nil {
+		utils.InternalError(c, err.Error())
+		return
+	}
+	utils.Paginated(c, notes, page, limit, total)
+}
+
+// GetTrendingNotes 热门笔记
+// @Summary 热门笔记
+// @Description 按带时间衰减的热度（阅读 1 分、点赞 5 分，按窗口的 1/3 为半衰期衰减）排序的公开笔记（无需鉴权）
+// @Tags 公开
+// @Produce json
+// @Param window query string false "时间窗口：day（默认）、week、month"
+// @Param limit query int false "返回数量，默认 10，最大 50"
+// @Success 200 {array} NoteSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/public/notes/trending [get]
+func (h *NoteHandler) GetTrendingNotes(c *gin.Context) {
+	window, ok := trending.ParseWindow(c.DefaultQuery("window", string(trending.WindowDay)))
+	if !ok {
+		utils.BadRequest(c, "invalid window: must be day, week or month")
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit <= 0 || limit > services.MaxTrendingNotes {
+		limit = 10
+	}
+	if h.trending == nil {
+		utils.OK(c, []models.Note{})
+		return
+	}
+	notes, err := h.trending.Trending(window, limit)
+	if err != nil {
+		utils.InternalError(c, err.Error())
+		return
+	}
+	utils.OK(c, notes)
+}
+
+// GetPublicNote 获取单条公开笔记
+// @Summary 获取公开笔记
+// @Description 根据 ID 获取公开笔记；笔记不存在或非公开时均返回 404（无需鉴权）
+// @Tags 公开
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Success 200 {object} NoteSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/public/notes/{id} [get]
+func (h *NoteHandler) GetPublicNote(c *gin.Context) {
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	note, err := h.svc.GetPublicNoteByID(id)
+	if err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			utils.NotFound(c, "note not found")
+			return
+		}
+		utils.InternalError(c, err.Error())
+		return
+	}
+
+	h.incrementViews(id)
+
+	c.Header("ETag", noteETag(note.Version))
+	utils.OK(c, note)
+}
+
+// GetNoteBySlug 通过永久链接获取笔记
+// @Summary 通过 slug 获取笔记
+// @Description 按作者用户名与 slug 获取笔记；旧 slug 返回 301 重定向到当前 slug。匿名仅可读公开笔记，作者可读自己的所有笔记，其余情况返回 404（可选鉴权）
+// @Tags 笔记
+// @Produce json
+// @Param username path string true "作者用户名"
+// @Param slug path string true "笔记 slug"
+// @Security BearerAuth
+// @Success 200 {object} NoteSwagger
+// @Success 301 "旧 slug，Location 指向当前 slug"
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/users/{username}/notes/{slug} [get]
+func (h *NoteHandler) GetNoteBySlug(c *gin.Context) {
+	userID, _ := utils.GetUserIDFromContext(c)
+	username := c.Param("username")
+	slug := c.Param("slug")
+	note, err := h.svc.GetNoteBySlug(userID, username, slug)
+	if err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			utils.NotFound(c, "note not found")
+			return
+		}
+		utils.InternalError(c, err.Error())
+		return
+	}
+	if note.Slug != slug {
+		location := "/api/v1/users/" + url.PathEscape(username) + "/notes/" + url.PathEscape(note.Slug)
+		if c.Request.URL.RawQuery != "" {
+			location += "?" + c.Request.URL.RawQuery
+		}
+		c.Redirect(http.StatusMovedPermanently, location)
+		return
+	}
+
+	h.incrementViews(note.ID)
+
+	c.Header("ETag", noteETag(note.Version))
+	utils.OK(c, note)
+}
+
+// parseTimeParam 解析时间查询参数，支持 RFC3339 与 2006-01-02 两种格式。
+// 对日期格式且 endOfDay 为 true 时返回次日零点，使上界包含当天。
+func parseTimeParam(s string, endOfDay bool) (*time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, true
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return nil, false
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, true
+}
+
+// SearchNotes 搜索当前用户的笔记
+// @Summary 搜索笔记
+// @Description 在当前用户的笔记中全文检索（标题权重高于摘要与正文），可按标签与创建时间范围过滤，支持排序与分页，结果带高亮片段（需要鉴权）
+// @Tags 笔记
+// @Produce json
+// @Param q query string false "关键字（websearch 语法：支持引号短语、or 与 -排除）"
+// @Param tags query string false "标签名，逗号分隔"
+// @Param match query string false "标签匹配方式：any（默认，任一）或 all（全部）"
+// @Param sort query string false "排序：relevance（有关键字时默认）、newest（无关键字时默认）、oldest、updated、views、likes"
+// @Param from query string false "创建时间下界（RFC3339 或 2006-01-02，含）"
+// @Param to query string false "创建时间上界（RFC3339 或 2006-01-02，日期格式包含当天）"
+// @Param status query string false "发布状态：draft、scheduled、published、unpublished"
+// @Param page query int false "页码"
+// @Param limit query int false "每页数量"
+// @Security BearerAuth
+// @Success 200 {array} NoteSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Router /api/v1/notes/search [get]
+func (h *NoteHandler) SearchNotes(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	opts := models.NoteSearchOptions{Query: c.Query("q")}
+	for _, t := range strings.Split(c.Query("tags"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			opts.Tags = append(opts.Tags, t)
+		}
+	}
+	switch c.DefaultQuery("match", "any") {
+	case "any":
+	case "all":
+		opts.MatchAll = true
+	default:
+		utils.BadRequest(c, "invalid match, expected any or all")
+		return
+	}
+	switch sort := c.Query("sort"); sort {
+	case "", models.NoteSortRelevance, models.NoteSortNewest, models.NoteSortOldest, models.NoteSortUpdated, models.NoteSortViews, models.NoteSortLikes:
+		opts.Sort = sort
+	default:
+		utils.BadRequest(c, "invalid sort")
+		return
+	}
+	if s := c.Query("from"); s != "" {
+		if opts.From, ok = parseTimeParam(s, false); !ok {
+			utils.BadRequest(c, "invalid from")
+			return
+		}
+	}
+	if s := c.Query("to"); s != "" {
+		if opts.To, ok = parseTimeParam(s, true); !ok {
+			utils.BadRequest(c, "invalid to")
+			return
+		}
+	}
+	switch status := c.Query("status"); status {
+	case "", models.NoteStatusDraft, models.NoteStatusScheduled, models.NoteStatusPublished, models.NoteStatusUnpublished:
+		opts.Status = status
+	default:
+		utils.BadRequest(c, "invalid status")
+		return
+	}
+
+	notes, total, err := h.svc.Search(userID, opts, page, limit)
+	if err != nil {
+		utils.InternalError(c, err.Error())
+		return
+	}
+	utils.Paginated(c, notes, page, limit, total)
+}
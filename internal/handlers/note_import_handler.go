@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"HYH-Blog-Gin/internal/services"
+	"HYH-Blog-Gin/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize 导入压缩包的最大字节数
+const maxImportSize = 50 << 20 // 50 MiB
+
+// NoteImportHandler 处理从 Markdown 压缩包导入笔记的请求。
+type NoteImportHandler struct {
+	svc services.NoteImportService
+}
+
+// NewNoteImportHandler 创建 NoteImportHandler 实例。
+func NewNoteImportHandler(svc services.NoteImportService) *NoteImportHandler {
+	return &NoteImportHandler{svc: svc}
+}
+
+// Import 从 Markdown 压缩包导入笔记
+// @Summary 导入笔记
+// @Description 上传 Hexo/Hugo 风格的 Markdown 压缩包（zip，最大 50 MiB，最多 1000 个 .md 文件），逐个文件创建笔记并返回每个文件的结果。
+// @Description 支持 YAML（---）与 TOML（+++）前置元数据：title、date、tags、summary、cover、draft；正文与封面引用的本地图片会上传并替换为新地址（需要鉴权）
+// @Tags 笔记
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Markdown 压缩包（zip）"
+// @Param dry_run query bool false "试运行：只解析与校验，不创建笔记也不上传图片"
+// @Security BearerAuth
+// @Success 200 {object} services.NoteImportReport
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/notes/import [post]
+func (h *NoteImportHandler) Import(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	if err := c.Request.ParseMultipartForm(maxImportSize); err != nil {
+		utils.BadRequest(c, "multipart form parse error or file too large")
+		return
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.BadRequest(c, "file 为必填字段")
+		return
+	}
+	f, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("打开导入文件失败: %v", err)
+		utils.InternalError(c, "读取上传文件失败")
+		return
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil {
+			log.Printf("关闭导入文件时出错: %v", cerr)
+		}
+	}()
+
+	report, err := h.svc.Import(userID, f, fileHeader.Size, dryRun)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidArchive) {
+			utils.BadRequest(c, err.Error())
+			return
+		}
+		utils.InternalError(c, err.Error())
+		return
+	}
+	utils.OK(c, report)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+
+	"HYH-Blog-Gin/internal/services"
+	"HYH-Blog-Gin/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoteLinkHandler 处理笔记出链与反向链接查询。
+type NoteLinkHandler struct {
+	svc services.NoteLinkService
+}
+
+// NewNoteLinkHandler 创建 NoteLinkHandler 实例。
+func NewNoteLinkHandler(svc services.NoteLinkService) *NoteLinkHandler {
+	return &NoteLinkHandler{svc: svc}
+}
+
+// writeNoteLinkError 将 service 错误映射为 HTTP 响应。
+func writeNoteLinkError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		utils.NotFound(c, "note not found")
+	case errors.Is(err, services.ErrForbidden):
+		utils.Forbidden(c, "forbidden")
+	default:
+		utils.InternalError(c, err.Error())
+	}
+}
+
+// Links 笔记出链
+// @Summary 笔记出链
+// @Description 列出正文中通过 [[note-id]]、[[Title]] 或 /notes/:id 引用的笔记，exists=false 表示目标已删除或不存在（可选鉴权）
+// @Tags 笔记
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Security BearerAuth
+// @Success 200 {array} NoteLinkSwagger
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/links [get]
+func (h *NoteLinkHandler) Links(c *gin.Context) {
+	// 可选鉴权：未登录时 userID 为 0，只能读取公开笔记
+	userID, _ := utils.GetUserIDFromContext(c)
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	items, err := h.svc.Links(userID, id)
+	if err != nil {
+		writeNoteLinkError(c, err)
+		return
+	}
+	utils.OK(c, items)
+}
+
+// Backlinks 笔记反向链接
+// @Summary 笔记反向链接
+// @Description 列出引用该笔记且对当前请求者可见的笔记，按更新时间倒序（可选鉴权）
+// @Tags 笔记
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Security BearerAuth
+// @Success 200 {array} NoteLinkSwagger
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/backlinks [get]
+func (h *NoteLinkHandler) Backlinks(c *gin.Context) {
+	userID, _ := utils.GetUserIDFromContext(c)
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	items, err := h.svc.Backlinks(userID, id)
+	if err != nil {
+		writeNoteLinkError(c, err)
+		return
+	}
+	utils.OK(c, items)
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"HYH-Blog-Gin/internal/services"
+	"HYH-Blog-Gin/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoteRevisionHandler 处理笔记修订历史相关请求。
+type NoteRevisionHandler struct {
+	svc services.NoteRevisionService
+}
+
+// NewNoteRevisionHandler 创建 NoteRevisionHandler 实例。
+func NewNoteRevisionHandler(svc services.NoteRevisionService) *NoteRevisionHandler {
+	return &NoteRevisionHandler{svc: svc}
+}
+
+// parseVersionParam 解析正整数版本号。
+func parseVersionParam(s string) (int, bool) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+	return v, true
+}
+
+// writeRevisionError 将 service 错误映射为 HTTP 响应。
+func writeRevisionError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrNotFound) {
+		utils.NotFound(c, "note or revision not found")
+		return
+	}
+	if errors.Is(err, services.ErrForbidden) {
+		utils.Forbidden(c, "forbidden")
+		return
+	}
+	if errors.Is(err, services.ErrVersionConflict) {
+		// 恢复期间笔记被并发修改
+		utils.Conflict(c, err.Error())
+		return
+	}
+	utils.InternalError(c, err.Error())
+}
+
+// List 列出笔记修订记录
+// @Summary 修订记录列表
+// @Description 按版本号倒序分页列出笔记的修订记录，仅作者可访问（需要鉴权）
+// @Tags 修订历史
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Param page query int false "页码"
+// @Param limit query int false "每页数量"
+// @Security BearerAuth
+// @Success 200 {array} NoteRevisionSwagger
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/revisions [get]
+func (h *NoteRevisionHandler) List(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	revs, total, err := h.svc.List(userID, id, page, limit)
+	if err != nil {
+		writeRevisionError(c, err)
+		return
+	}
+	utils.Paginated(c, revs, page, limit, total)
+}
+
+// Get 获取单个修订版本
+// @Summary 获取修订版本
+// @Description 获取笔记的指定修订版本，仅作者可访问（需要鉴权）
+// @Tags 修订历史
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Param version path int true "版本号"
+// @Security BearerAuth
+// @Success 200 {object} NoteRevisionSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/revisions/{version} [get]
+func (h *NoteRevisionHandler) Get(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	version, ok := parseVersionParam(c.Param("version"))
+	if !ok {
+		utils.BadRequest(c, "invalid version")
+		return
+	}
+	rev, err := h.svc.Get(userID, id, version)
+	if err != nil {
+		writeRevisionError(c, err)
+		return
+	}
+	utils.OK(c, rev)
+}
+
+// Diff 对比两个修订版本
+// @Summary 修订版本对比
+// @Description 返回 from → to 两个版本之间标题、摘要、正文的行级差异与标签增删，仅作者可访问（需要鉴权）
+// @Tags 修订历史
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Param from query int true "起始版本号"
+// @Param to query int true "目标版本号"
+// @Security BearerAuth
+// @Success 200 {object} services.NoteRevisionDiff
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/revisions/diff [get]
+func (h *NoteRevisionHandler) Diff(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	from, ok := parseVersionParam(c.Query("from"))
+	if !ok {
+		utils.BadRequest(c, "invalid from")
+		return
+	}
+	to, ok := parseVersionParam(c.Query("to"))
+	if !ok {
+		utils.BadRequest(c, "invalid to")
+		return
+	}
+	diff, err := h.svc.Diff(userID, id, from, to)
+	if err != nil {
+		writeRevisionError(c, err)
+		return
+	}
+	utils.OK(c, diff)
+}
+
+// Restore 恢复到指定修订版本
+// @Summary 恢复修订版本
+// @Description 将笔记的标题、摘要、正文与标签恢复为指定版本，并作为一次新的更新记录修订，仅作者可操作（需要鉴权）
+// @Tags 修订历史
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Param version path int true "版本号"
+// @Security BearerAuth
+// @Success 200 {object} NoteSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/revisions/{version}/restore [post]
+func (h *NoteRevisionHandler) Restore(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	version, ok := parseVersionParam(c.Param("version"))
+	if !ok {
+		utils.BadRequest(c, "invalid version")
+		return
+	}
+	note, err := h.svc.Restore(userID, id, version)
+	if err != nil {
+		writeRevisionError(c, err)
+		return
+	}
+	utils.OK(c, note)
+}
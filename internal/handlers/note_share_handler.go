@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"HYH-Blog-Gin/internal/services"
+	"HYH-Blog-Gin/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoteShareHandler 处理笔记分享链接相关请求。
+type NoteShareHandler struct {
+	svc services.NoteShareService
+}
+
+// NewNoteShareHandler 创建 NoteShareHandler 实例。
+func NewNoteShareHandler(svc services.NoteShareService) *NoteShareHandler {
+	return &NoteShareHandler{svc: svc}
+}
+
+// NoteShareRequest 创建分享链接的请求体，字段均可选；省略表示不限制。
+type NoteShareRequest struct {
+	ExpiresAt *time.Time `json:"expires_at" example:"2030-01-01T00:00:00Z"`
+	Password  string     `json:"password" example:"s3cret"`
+	MaxViews  *int       `json:"max_views" example:"10"`
+}
+
+// writeShareError 将 service 错误映射为 HTTP 响应。
+func writeShareError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		utils.NotFound(c, "note not found")
+	case errors.Is(err, services.ErrShareNotFound):
+		utils.NotFound(c, err.Error())
+	case errors.Is(err, services.ErrForbidden):
+		utils.Forbidden(c, "forbidden")
+	case errors.Is(err, services.ErrShareExpired):
+		utils.Gone(c, err.Error())
+	case errors.Is(err, services.ErrSharePassword):
+		utils.Unauthorized(c, err.Error())
+	case errors.Is(err, services.ErrInvalidShare), errors.Is(err, services.ErrTooManyShares):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalError(c, err.Error())
+	}
+}
+
+// Create 创建分享链接
+// @Summary 创建分享链接
+// @Description 为笔记生成随机 Token，持有者无需登录即可通过 /s/{token} 读取笔记（包括未公开的笔记）；可选到期时间、访问密码与最大访问次数，每篇笔记最多 50 个（仅作者，需要鉴权）
+// @Tags 分享
+// @Accept json
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Param payload body NoteShareRequest false "分享限制"
+// @Security BearerAuth
+// @Success 201 {object} NoteShareSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/shares [post]
+func (h *NoteShareHandler) Create(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	noteID, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	// 请求体可省略
+	var req NoteShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BadRequest(c, err.Error())
+		return
+	}
+	share, err := h.svc.Create(userID, noteID, services.NoteShareInput{ExpiresAt: req.ExpiresAt, Password: req.Password, MaxViews: req.MaxViews})
+	if err != nil {
+		writeShareError(c, err)
+		return
+	}
+	utils.Created(c, share)
+}
+
+// List 分享链接列表
+// @Summary 分享链接列表
+// @Description 按创建时间倒序列出笔记的全部分享链接（含已到期的），附带访问次数（仅作者，需要鉴权）
+// @Tags 分享
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Security BearerAuth
+// @Success 200 {array} NoteShareSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/shares [get]
+func (h *NoteShareHandler) List(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	noteID, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	shares, err := h.svc.List(userID, noteID)
+	if err != nil {
+		writeShareError(c, err)
+		return
+	}
+	utils.OK(c, shares)
+}
+
+// Revoke 撤销分享链接
+// @Summary 撤销分享链接
+// @Description 删除分享链接，之后凭该 Token 访问返回 404（仅作者，需要鉴权）
+// @Tags 分享
+// @Produce json
+// @Param id path int true "笔记 ID"
+// @Param share_id path int true "分享链接 ID"
+// @Security BearerAuth
+// @Success 200 {object} SimpleMessage
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/notes/{id}/shares/{share_id} [delete]
+func (h *NoteShareHandler) Revoke(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	noteID, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	shareID, ok := parseUintParam(c.Param("share_id"))
+	if !ok {
+		utils.BadRequest(c, "invalid share_id")
+		return
+	}
+	if err := h.svc.Revoke(userID, noteID, shareID); err != nil {
+		writeShareError(c, err)
+		return
+	}
+	utils.OKMsg(c, "share revoked", nil)
+}
+
+// Open 通过分享链接读取笔记
+// @Summary 通过分享链接读取笔记
+// @Description 无需登录，凭 Token 读取笔记（含渲染后的 content_html 与目录）并计入一次访问；设置了密码时通过 X-Share-Password 请求头提供（不接受查询参数，避免密码进入浏览器历史与访问日志）。链接不存在或已撤销返回 404，到期或访问次数用尽返回 410，密码缺失或错误返回 401（不计入访问次数）
+// @Tags 分享
+// @Produce json
+// @Param token path string true "分享 Token"
+// @Param X-Share-Password header string false "访问密码"
+// @Success 200 {object} NoteSwagger
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 410 {object} map[string]interface{}
+// @Failure 429 {object} map[string]interface{}
+// @Router /s/{token} [get]
+func (h *NoteShareHandler) Open(c *gin.Context) {
+	// 分享的内容可能未公开：禁止缓存与搜索引擎收录
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+	note, err := h.svc.Open(c.Param("token"), c.GetHeader("X-Share-Password"))
+	if err != nil {
+		writeShareError(c, err)
+		return
+	}
+	utils.OK(c, note)
+}
@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"HYH-Blog-Gin/internal/services"
+	"HYH-Blog-Gin/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SeriesHandler 处理笔记系列相关请求。
+type SeriesHandler struct {
+	svc services.SeriesService
+}
+
+// NewSeriesHandler 创建 SeriesHandler 实例。
+func NewSeriesHandler(svc services.SeriesService) *SeriesHandler {
+	return &SeriesHandler{svc: svc}
+}
+
+// SeriesCreateRequest 创建系列请求体
+type SeriesCreateRequest struct {
+	Title       string `json:"title" binding:"required" example:"Building a blog with Gin"`
+	Description string `json:"description" example:"A step-by-step tutorial"`
+}
+
+// SeriesUpdateRequest 更新系列请求体，省略的字段保持不变
+type SeriesUpdateRequest struct {
+	Title       *string `json:"title" example:"Building a blog with Gin"`
+	Description *string `json:"description" example:"A step-by-step tutorial"`
+}
+
+// SeriesInsertRequest 插入成员笔记请求体，position 从 1 开始，省略时追加到末尾
+type SeriesInsertRequest struct {
+	NoteID   uint `json:"note_id" binding:"required" example:"12"`
+	Position *int `json:"position" example:"2"`
+}
+
+// SeriesReorderRequest 重排成员笔记请求体，note_ids 必须恰好包含当前全部成员
+type SeriesReorderRequest struct {
+	NoteIDs []uint `json:"note_ids" binding:"required"`
+}
+
+// writeSeriesError 将 service 错误映射为 HTTP 响应。
+func writeSeriesError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		utils.NotFound(c, "series or note not found")
+	case errors.Is(err, services.ErrForbidden):
+		utils.Forbidden(c, "forbidden")
+	case errors.Is(err, services.ErrNoteInSeries):
+		utils.Conflict(c, err.Error())
+	case errors.Is(err, services.ErrInvalidSeries), errors.Is(err, services.ErrInvalidOrder):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalError(c, err.Error())
+	}
+}
+
+// parsePage 解析分页参数，limit 超出范围时使用默认值。
+func parsePage(c *gin.Context, defaultLimit int) (int, int) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultLimit)))
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = defaultLimit
+	}
+	return page, limit
+}
+
+// Create 创建系列
+// @Summary 创建系列
+// @Description 创建一个空的笔记系列（需要鉴权）
+// @Tags 系列
+// @Accept json
+// @Produce json
+// @Param payload body SeriesCreateRequest true "系列信息"
+// @Security BearerAuth
+// @Success 201 {object} SeriesSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/series [post]
+func (h *SeriesHandler) Create(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	var req SeriesCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, err.Error())
+		return
+	}
+	series, err := h.svc.Create(userID, services.SeriesInput{Title: &req.Title, Description: &req.Description})
+	if err != nil {
+		writeSeriesError(c, err)
+		return
+	}
+	utils.Created(c, series)
+}
+
+// Get 获取系列
+// @Summary 获取系列
+// @Description 获取系列及其有序成员笔记；非作者只能读取公开系列（全部成员笔记公开）（可选鉴权）
+// @Tags 系列
+// @Produce json
+// @Param id path int true "系列 ID"
+// @Security BearerAuth
+// @Success 200 {object} SeriesSwagger
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/series/{id} [get]
+func (h *SeriesHandler) Get(c *gin.Context) {
+	// 可选鉴权：未登录时 userID 为 0，只能读取公开系列
+	userID, _ := utils.GetUserIDFromContext(c)
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	series, err := h.svc.Get(userID, id)
+	if err != nil {
+		writeSeriesError(c, err)
+		return
+	}
+	utils.OK(c, series)
+}
+
+// ListMine 我的系列
+// @Summary 我的系列
+// @Description 按更新时间倒序分页列出当前用户的全部系列（需要鉴权）
+// @Tags 系列
+// @Produce json
+// @Param page query int false "页码"
+// @Param limit query int false "每页数量"
+// @Security BearerAuth
+// @Success 200 {array} SeriesSwagger
+// @Router /api/v1/series [get]
+func (h *SeriesHandler) ListMine(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	page, limit := parsePage(c, 20)
+	list, total, err := h.svc.ListMine(userID, page, limit)
+	if err != nil {
+		writeSeriesError(c, err)
+		return
+	}
+	utils.Paginated(c, list, page, limit, total)
+}
+
+// ListPublic 公开系列列表
+// @Summary 公开系列列表
+// @Description 按更新时间倒序分页列出全部成员笔记均公开的系列，可按作者过滤（无需鉴权）
+// @Tags 公开
+// @Produce json
+// @Param author_id query int false "作者 ID"
+// @Param page query int false "页码"
+// @Param limit query int false "每页数量"
+// @Success 200 {array} SeriesSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Router /api/v1/public/series [get]
+func (h *SeriesHandler) ListPublic(c *gin.Context) {
+	page, limit := parsePage(c, 10)
+	var authorID uint
+	if s := c.Query("author_id"); s != "" {
+		id, ok := parseUintParam(s)
+		if !ok {
+			utils.BadRequest(c, "invalid author_id")
+			return
+		}
+		authorID = id
+	}
+	list, total, err := h.svc.ListPublic(authorID, page, limit)
+	if err != nil {
+		writeSeriesError(c, err)
+		return
+	}
+	utils.Paginated(c, list, page, limit, total)
+}
+
+// Update 更新系列
+// @Summary 更新系列
+// @Description 更新系列标题与简介，仅作者可操作（需要鉴权）
+// @Tags 系列
+// @Accept json
+// @Produce json
+// @Param id path int true "系列 ID"
+// @Param payload body SeriesUpdateRequest true "系列信息"
+// @Security BearerAuth
+// @Success 200 {object} SeriesSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/series/{id} [put]
+func (h *SeriesHandler) Update(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	var req SeriesUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, err.Error())
+		return
+	}
+	series, err := h.svc.Update(userID, id, services.SeriesInput{Title: req.Title, Description: req.Description})
+	if err != nil {
+		writeSeriesError(c, err)
+		return
+	}
+	utils.OK(c, series)
+}
+
+// Delete 删除系列
+// @Summary 删除系列
+// @Description 删除系列，成员笔记本身保留，仅作者可操作（需要鉴权）
+// @Tags 系列
+// @Param id path int true "系列 ID"
+// @Security BearerAuth
+// @Success 200 {object} SimpleMessage
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/series/{id} [delete]
+func (h *SeriesHandler) Delete(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	if err := h.svc.Delete(userID, id); err != nil {
+		writeSeriesError(c, err)
+		return
+	}
+	utils.OKMsg(c, "series deleted successfully", nil)
+}
+
+// InsertNote 插入成员笔记
+// @Summary 向系列插入笔记
+// @Description 将自己的笔记插入到指定位置（从 1 开始，省略时追加到末尾）；笔记已在本系列中时移动到新位置，已属于其他系列时返回 409（需要鉴权）
+// @Tags 系列
+// @Accept json
+// @Produce json
+// @Param id path int true "系列 ID"
+// @Param payload body SeriesInsertRequest true "笔记与位置"
+// @Security BearerAuth
+// @Success 200 {object} SeriesSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Router /api/v1/series/{id}/notes [post]
+func (h *SeriesHandler) InsertNote(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	var req SeriesInsertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, err.Error())
+		return
+	}
+	series, err := h.svc.InsertNote(userID, id, req.NoteID, req.Position)
+	if err != nil {
+		writeSeriesError(c, err)
+		return
+	}
+	utils.OK(c, series)
+}
+
+// Reorder 重排成员笔记
+// @Summary 重排系列
+// @Description 按 note_ids 的顺序重排系列，note_ids 必须恰好包含当前全部成员笔记（需要鉴权）
+// @Tags 系列
+// @Accept json
+// @Produce json
+// @Param id path int true "系列 ID"
+// @Param payload body SeriesReorderRequest true "新的顺序"
+// @Security BearerAuth
+// @Success 200 {object} SeriesSwagger
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/series/{id}/notes [put]
+func (h *SeriesHandler) Reorder(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	var req SeriesReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, err.Error())
+		return
+	}
+	series, err := h.svc.Reorder(userID, id, req.NoteIDs)
+	if err != nil {
+		writeSeriesError(c, err)
+		return
+	}
+	utils.OK(c, series)
+}
+
+// RemoveNote 移除成员笔记
+// @Summary 从系列移除笔记
+// @Description 从系列中移除笔记，笔记本身保留（需要鉴权）
+// @Tags 系列
+// @Produce json
+// @Param id path int true "系列 ID"
+// @Param note_id path int true "笔记 ID"
+// @Security BearerAuth
+// @Success 200 {object} SeriesSwagger
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/series/{id}/notes/{note_id} [delete]
+func (h *SeriesHandler) RemoveNote(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		utils.Unauthorized(c, "unauthorized")
+		return
+	}
+	id, ok := parseUintParam(c.Param("id"))
+	if !ok {
+		utils.BadRequest(c, "invalid id")
+		return
+	}
+	noteID, ok := parseUintParam(c.Param("note_id"))
+	if !ok {
+		utils.BadRequest(c, "invalid note_id")
+		return
+	}
+	series, err := h.svc.RemoveNote(userID, id, noteID)
+	if err != nil {
+		writeSeriesError(c, err)
+		return
+	}
+	utils.OK(c, series)
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"HYH-Blog-Gin/internal/cache"
+	"HYH-Blog-Gin/internal/services"
+	"HYH-Blog-Gin/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SitemapHandler 提供站点地图与 robots.txt。站点地图按公开内容缓存代数缓存在 cache 中，
+// 公开笔记变化时代数递增，下一次请求重新生成。
+type SitemapHandler struct {
+	svc   services.SitemapService
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewSitemapHandler 创建 SitemapHandler 实例，ttl<=0 表示不缓存。
+func NewSitemapHandler(svc services.SitemapService, c cache.Cache, ttl time.Duration) *SitemapHandler {
+	if c == nil {
+		c = cache.NewNoOpCache()
+	}
+	return &SitemapHandler{svc: svc, cache: c, ttl: ttl}
+}
+
+// Sitemap 站点地图
+// @Summary 站点地图
+// @Description 列出首页、作者页、标签页与全部公开笔记（lastmod 为 updated_at）；超过 50000 条地址时返回 sitemapindex，分页位于 /sitemap/{n}.xml
+// @Tags 订阅
+// @Produce xml
+// @Success 200 {string} string "sitemap XML"
+// @Router /sitemap.xml [get]
+func (h *SitemapHandler) Sitemap(c *gin.Context) {
+	h.serve(c, 0)
+}
+
+// SitemapPage 站点地图分页
+// @Summary 站点地图分页
+// @Description 地址超过 50000 条时由 /sitemap.xml 索引的分页，n 从 1 开始
+// @Tags 订阅
+// @Produce xml
+// @Param file path string true "分页文件名，如 1.xml"
+// @Success 200 {string} string "sitemap XML"
+// @Failure 404 {object} map[string]interface{}
+// @Router /sitemap/{file} [get]
+func (h *SitemapHandler) SitemapPage(c *gin.Context) {
+	n, err := strconv.Atoi(strings.TrimSuffix(c.Param("file"), ".xml"))
+	if err != nil || n <= 0 || !strings.HasSuffix(c.Param("file"), ".xml") {
+		utils.NotFound(c, "sitemap not found")
+		return
+	}
+	h.serve(c, n)
+}
+
+// Robots robots.txt
+// @Summary robots.txt
+// @Description 由 ROBOTS_DISALLOW 生成并声明站点地图地址，或原样返回 ROBOTS_FILE 的内容
+// @Tags 订阅
+// @Produce plain
+// @Success 200 {string} string "robots.txt"
+// @Router /robots.txt [get]
+func (h *SitemapHandler) Robots(c *gin.Context) {
+	c.String(http.StatusOK, h.svc.Robots())
+}
+
+// serve 返回第 page 个站点地图文件（0 表示 /sitemap.xml），缓存未命中时重新生成并缓存全部文件。
+func (h *SitemapHandler) serve(c *gin.Context, page int) {
+	ctx := c.Request.Context()
+	kg := cache.NewKeyGenerator()
+	gen, _ := h.cache.GetInteger(ctx, cache.PublicGenerationKey)
+
+	var body string
+	if hit, err := h.cache.Get(ctx, kg.Sitemap(gen, page), &body); err != nil || !hit {
+		files, err := h.svc.Generate()
+		if err != nil {
+			utils.InternalError(c, err.Error())
+			return
+		}
+		if h.ttl > 0 {
+			_ = h.cache.Set(ctx, kg.Sitemap(gen, 0), files.Root, h.ttl)
+			for i, p := range files.Pages {
+				_ = h.cache.Set(ctx, kg.Sitemap(gen, i+1), p, h.ttl)
+			}
+		}
+		switch {
+		case page == 0:
+			body = files.Root
+		case page <= len(files.Pages):
+			body = files.Pages[page-1]
+		default:
+			utils.NotFound(c, "sitemap not found")
+			return
+		}
+	}
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", []byte(body))
+}
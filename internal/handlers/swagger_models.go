@@ -6,19 +6,41 @@ import "time"
 
 // NoteSwagger 用于 Swagger 显示 Note 数据结构（简化版）
 type NoteSwagger struct {
-	ID         uint         `json:"id" example:"1"`
-	Title      string       `json:"title" example:"Hello world"`
-	Summary    string       `json:"summary" example:"A short summary"`
-	Content    string       `json:"content" example:"Detailed content of the note..."`
-	CoverImage string       `json:"cover_image" example:"/static/images/cover.webp"`
-	AuthorID   uint         `json:"author_id" example:"1"`
-	Author     *UserSwagger `json:"author,omitempty"`
-	Tags       []TagSwagger `json:"tags,omitempty"`
-	IsPublic   bool         `json:"is_public" example:"true"`
-	Views      int64        `json:"views" example:"123"`
-	Likes      int64        `json:"likes" example:"10"`
-	CreatedAt  time.Time    `json:"createdAt"`
-	UpdatedAt  time.Time    `json:"updatedAt"`
+	ID             uint                      `json:"id" example:"1"`
+	Title          string                    `json:"title" example:"Hello world"`
+	Slug           string                    `json:"slug" example:"hello-world"`
+	Summary        string                    `json:"summary" example:"A short summary"`
+	Content        string                    `json:"content" example:"Detailed content of the note..."`
+	ContentHTML    string                    `json:"content_html,omitempty" example:"<p>Detailed content of the note...</p>"`
+	TOC            []TOCItemSwagger          `json:"toc,omitempty"`
+	CoverImage     string                    `json:"cover_image" example:"/static/images/cover.webp"`
+	AuthorID       uint                      `json:"author_id" example:"1"`
+	Author         *UserSwagger              `json:"author,omitempty"`
+	Tags           []TagSwagger              `json:"tags,omitempty"`
+	IsPublic       bool                      `json:"is_public" example:"true"`
+	Unlisted       bool                      `json:"unlisted" example:"false"`
+	Status         string                    `json:"status" example:"published"`
+	PublishAt      *time.Time                `json:"publish_at,omitempty"`
+	ExpiresAt      *time.Time                `json:"expires_at,omitempty"`
+	PublishedAt    *time.Time                `json:"published_at,omitempty"`
+	Version        int64                     `json:"version" example:"3"`
+	Views          int64                     `json:"views" example:"123"`
+	Likes          int64                     `json:"likes" example:"10"`
+	WordCount      int                       `json:"word_count" example:"1200"`
+	ReadingMinutes int                       `json:"reading_minutes" example:"4"`
+	LikedByMe      *bool                     `json:"liked_by_me,omitempty" example:"true"`
+	BookmarkedByMe *bool                     `json:"bookmarked_by_me,omitempty" example:"false"`
+	Series         *NoteSeriesContextSwagger `json:"series,omitempty"`
+	CreatedAt      time.Time                 `json:"createdAt"`
+	UpdatedAt      time.Time                 `json:"updatedAt"`
+}
+
+// TOCItemSwagger 用于 Swagger 显示笔记目录项
+type TOCItemSwagger struct {
+	Level    int              `json:"level" example:"2"`
+	Text     string           `json:"text" example:"Getting started"`
+	ID       string           `json:"id" example:"getting-started"`
+	Children []TOCItemSwagger `json:"children,omitempty"`
 }
 
 // UserSwagger 用于 Swagger 显示 User（简化版）
@@ -45,3 +67,123 @@ type ImageMetaSwagger struct {
 	Size    int    `json:"size"`
 	ModTime string `json:"mod_time"`
 }
+
+// NoteRevisionSwagger 用于 Swagger 显示笔记修订记录
+type NoteRevisionSwagger struct {
+	ID        uint      `json:"id" example:"1"`
+	NoteID    uint      `json:"note_id" example:"1"`
+	Version   int       `json:"version" example:"3"`
+	Title     string    `json:"title" example:"Hello world"`
+	Summary   string    `json:"summary" example:"A short summary"`
+	Content   string    `json:"content" example:"Detailed content of the note..."`
+	Tags      []string  `json:"tags"`
+	EditorID  uint      `json:"editor_id" example:"1"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CommentSwagger 用于 Swagger 显示评论（含嵌套回复）
+type CommentSwagger struct {
+	ID        uint             `json:"id" example:"1"`
+	NoteID    uint             `json:"note_id" example:"1"`
+	AuthorID  uint             `json:"author_id" example:"2"`
+	Author    *UserSwagger     `json:"author,omitempty"`
+	ParentID  *uint            `json:"parent_id" example:"1"`
+	RootID    *uint            `json:"root_id" example:"1"`
+	Content   string           `json:"content" example:"Nice post!"`
+	EditedAt  *time.Time       `json:"edited_at,omitempty"`
+	DeletedAt *time.Time       `json:"deleted_at,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+	Replies   []CommentSwagger `json:"replies,omitempty"`
+}
+
+// BookmarkSwagger 用于 Swagger 显示收藏（列表中附带笔记）
+type BookmarkSwagger struct {
+	ID         uint         `json:"id" example:"1"`
+	UserID     uint         `json:"user_id" example:"2"`
+	NoteID     uint         `json:"note_id" example:"1"`
+	Note       *NoteSwagger `json:"note,omitempty"`
+	Folder     string       `json:"folder" example:"to-read"`
+	Annotation string       `json:"annotation" example:"Revisit the caching section"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	UpdatedAt  time.Time    `json:"updatedAt"`
+}
+
+// NoteShareSwagger 用于 Swagger 显示分享链接
+type NoteShareSwagger struct {
+	ID           uint       `json:"id" example:"1"`
+	NoteID       uint       `json:"note_id" example:"1"`
+	Token        string     `json:"token" example:"3f9c2a7e5b1d4c8fa0e6b2d9c7a14e53"`
+	HasPassword  bool       `json:"has_password" example:"true"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	MaxViews     *int       `json:"max_views,omitempty" example:"10"`
+	Views        int        `json:"views" example:"3"`
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+// BookmarkFolderSwagger 用于 Swagger 显示收藏夹
+type BookmarkFolderSwagger struct {
+	Name  string `json:"name" example:"to-read"`
+	Count int64  `json:"count" example:"3"`
+}
+
+// SeriesSwagger 用于 Swagger 显示笔记系列
+type SeriesSwagger struct {
+	ID          uint                `json:"id" example:"1"`
+	AuthorID    uint                `json:"author_id" example:"1"`
+	Author      *UserSwagger        `json:"author,omitempty"`
+	Title       string              `json:"title" example:"Building a blog with Gin"`
+	Description string              `json:"description" example:"A step-by-step tutorial"`
+	NoteCount   int64               `json:"note_count" example:"3"`
+	Notes       []SeriesItemSwagger `json:"notes,omitempty"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+}
+
+// SeriesItemSwagger 用于 Swagger 显示系列中的一篇笔记
+type SeriesItemSwagger struct {
+	NoteID    uint       `json:"note_id" example:"1"`
+	Title     string     `json:"title" example:"Part 1: Setup"`
+	Slug      string     `json:"slug" example:"part-1-setup"`
+	Status    string     `json:"status" example:"published"`
+	IsPublic  bool       `json:"is_public" example:"true"`
+	Unlisted  bool       `json:"unlisted" example:"false"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// NoteSeriesContextSwagger 用于 Swagger 显示笔记在系列中的位置
+type NoteSeriesContextSwagger struct {
+	ID       uint               `json:"id" example:"1"`
+	Title    string             `json:"title" example:"Building a blog with Gin"`
+	Position int                `json:"position" example:"2"`
+	Total    int                `json:"total" example:"3"`
+	Prev     *SeriesItemSwagger `json:"prev,omitempty"`
+	Next     *SeriesItemSwagger `json:"next,omitempty"`
+}
+
+// NoteLinkSwagger 用于 Swagger 显示出链/反向链接中的一篇笔记
+type NoteLinkSwagger struct {
+	NoteID *uint  `json:"note_id" example:"2"`
+	Title  string `json:"title" example:"Getting started"`
+	Slug   string `json:"slug" example:"getting-started"`
+	Exists bool   `json:"exists" example:"true"`
+}
+
+// RelatedNoteSwagger 用于 Swagger 显示相关阅读推荐中的一篇笔记
+type RelatedNoteSwagger struct {
+	ID          uint    `json:"id" example:"7"`
+	Title       string  `json:"title" example:"Goroutine leaks"`
+	Slug        string  `json:"slug" example:"goroutine-leaks"`
+	Summary     string  `json:"summary" example:"A short summary"`
+	CoverImage  string  `json:"cover_image" example:"/static/images/cover.webp"`
+	AuthorID    uint    `json:"author_id" example:"1"`
+	Username    string  `json:"username" example:"alice"`
+	SharedTags  int     `json:"shared_tags" example:"2"`
+	Jaccard     float64 `json:"jaccard" example:"0.5"`
+	Views       int64   `json:"views" example:"123"`
+	Likes       int64   `json:"likes" example:"10"`
+	PublishedAt string  `json:"published_at" example:"2025-01-01T00:00:00Z"`
+	UpdatedAt   string  `json:"updated_at" example:"2025-01-02T00:00:00Z"`
+	Score       float64 `json:"score" example:"0.72"`
+}
@@ -0,0 +1,266 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+	"go.yaml.in/yaml/v3"
+)
+
+// ErrFrontMatter 前置元数据缺少结束分隔符或无法解析
+var ErrFrontMatter = errors.New("invalid front matter")
+
+// FrontMatter 从 Hexo/Hugo 风格文章头部解析出的元数据，未提供的字段为零值。
+type FrontMatter struct {
+	Title     string
+	Date      *time.Time // date
+	Updated   *time.Time // updated（Hexo）或 lastmod（Hugo）
+	PublishAt *time.Time // publish_at（本站导出的定时发布时间）
+	ExpiresAt *time.Time // expires_at（本站导出的过期时间）
+	Tags      []string
+	Summary   string // summary 或 description
+	Cover     string // cover、cover_image 或 image；Hugo 主题常用的 cover.image 也可识别
+	Draft     bool   // draft: true 或 published: false（Hexo）
+	Unlisted  bool   // unlisted: true（本站导出的不公开列出笔记）
+}
+
+// frontMatterDateLayouts 字符串形式日期可接受的格式，无时区时按本地时区解析
+var frontMatterDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseFrontMatter 拆分文章头部的前置元数据与正文：以 --- 包裹的为 YAML，以 +++ 包裹的为 TOML。
+// 没有前置元数据时返回零值与原文。
+func ParseFrontMatter(src []byte) (FrontMatter, string, error) {
+	src = bytes.TrimPrefix(src, []byte("\xef\xbb\xbf"))
+	content := strings.ReplaceAll(string(src), "\r\n", "\n")
+
+	var delim string
+	switch {
+	case strings.HasPrefix(content, "---\n"):
+		delim = "---"
+	case strings.HasPrefix(content, "+++\n"):
+		delim = "+++"
+	default:
+		return FrontMatter{}, content, nil
+	}
+	rest := content[len(delim)+1:]
+	var head, body string
+	if strings.HasPrefix(rest, delim+"\n") || rest == delim {
+		body = strings.TrimPrefix(rest, delim)
+	} else {
+		end := strings.Index(rest, "\n"+delim+"\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n"+delim) {
+				return FrontMatter{}, "", fmt.Errorf("%w: missing closing %s", ErrFrontMatter, delim)
+			}
+			end = len(rest) - len(delim) - 1
+		}
+		head, body = rest[:end], rest[end+len(delim)+1:]
+	}
+
+	raw := map[string]interface{}{}
+	var err error
+	if delim == "---" {
+		raw, err = decodeYAML(head)
+	} else {
+		err = toml.Unmarshal([]byte(head), &raw)
+	}
+	if err != nil {
+		return FrontMatter{}, "", fmt.Errorf("%w: %v", ErrFrontMatter, err)
+	}
+	fm, err := frontMatterFromMap(raw)
+	if err != nil {
+		return FrontMatter{}, "", err
+	}
+	return fm, strings.TrimLeft(body, "\n"), nil
+}
+
+// decodeYAML 解析 YAML 前置元数据。时间戳保留为原始字符串，
+// 以便没有时区的日期（如 Hexo 的 2020-01-02 15:04:05）按本地时区而不是 UTC 解析。
+func decodeYAML(head string) (map[string]interface{}, error) {
+	nodes := map[string]yaml.Node{}
+	if err := yaml.Unmarshal([]byte(head), &nodes); err != nil {
+		return nil, err
+	}
+	raw := make(map[string]interface{}, len(nodes))
+	for k, node := range nodes {
+		if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!timestamp" {
+			raw[k] = node.Value
+			continue
+		}
+		var v interface{}
+		if err := node.Decode(&v); err != nil {
+			return nil, err
+		}
+		raw[k] = v
+	}
+	return raw, nil
+}
+
+// frontMatterFromMap 从解析后的键值中提取已知字段，键名不区分大小写，未知字段忽略。
+func frontMatterFromMap(raw map[string]interface{}) (FrontMatter, error) {
+	fields := make(map[string]interface{}, len(raw))
+	for k, v := range raw {
+		fields[strings.ToLower(k)] = v
+	}
+	str := func(keys ...string) string {
+		for _, k := range keys {
+			if s, ok := fields[k].(string); ok && strings.TrimSpace(s) != "" {
+				return strings.TrimSpace(s)
+			}
+		}
+		return ""
+	}
+
+	var fm FrontMatter
+	var err error
+	fm.Title = str("title")
+	fm.Summary = str("summary", "description")
+	fm.Cover = str("cover", "cover_image", "image")
+	if cover, ok := fields["cover"].(map[string]interface{}); ok && fm.Cover == "" {
+		if s, ok := cover["image"].(string); ok {
+			fm.Cover = strings.TrimSpace(s)
+		}
+	}
+	if fm.Date, err = frontMatterTime(fields["date"]); err != nil {
+		return FrontMatter{}, err
+	}
+	updated := fields["updated"]
+	if updated == nil {
+		updated = fields["lastmod"]
+	}
+	if fm.Updated, err = frontMatterTime(updated); err != nil {
+		return FrontMatter{}, err
+	}
+	if fm.PublishAt, err = frontMatterTime(fields["publish_at"]); err != nil {
+		return FrontMatter{}, err
+	}
+	if fm.ExpiresAt, err = frontMatterTime(fields["expires_at"]); err != nil {
+		return FrontMatter{}, err
+	}
+
+	switch tags := fields["tags"].(type) {
+	case string:
+		for _, t := range strings.Split(tags, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				fm.Tags = append(fm.Tags, t)
+			}
+		}
+	case []interface{}:
+		for _, t := range tags {
+			if t == nil {
+				continue
+			}
+			if s := strings.TrimSpace(fmt.Sprint(t)); s != "" {
+				fm.Tags = append(fm.Tags, s)
+			}
+		}
+	}
+
+	if draft, ok := fields["draft"].(bool); ok {
+		fm.Draft = draft
+	}
+	if published, ok := fields["published"].(bool); ok && !published {
+		fm.Draft = true
+	}
+	if unlisted, ok := fields["unlisted"].(bool); ok {
+		fm.Unlisted = unlisted
+	}
+	return fm, nil
+}
+
+// frontMatterTime 把 YAML/TOML 中的日期（时间值、TOML 本地日期/时间或字符串）转换为 time.Time。
+func frontMatterTime(v interface{}) (*time.Time, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		return &t, nil
+	case interface {
+		AsTime(*time.Location) time.Time
+	}:
+		tt := t.AsTime(time.Local)
+		return &tt, nil
+	case string:
+		s := strings.TrimSpace(t)
+		if s == "" {
+			return nil, nil
+		}
+		for _, layout := range frontMatterDateLayouts {
+			if tt, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				return &tt, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: unrecognized date %v", ErrFrontMatter, v)
+}
+
+// imgSrcRe 匹配原始 HTML 中 <img> 的 src 属性
+var imgSrcRe = regexp.MustCompile(`(?i)<img\b[^>]*?\bsrc\s*=\s*["']([^"']+)["']`)
+
+// ImageRefs 按首次出现的顺序返回正文中引用的图片地址（Markdown 图片与原始 HTML 中的 <img>），代码块中的内容不计入。
+func ImageRefs(src string) []string {
+	source := []byte(src)
+	doc := textParser.Parse(text.NewReader(source))
+
+	var refs []string
+	seen := make(map[string]struct{})
+	add := func(ref string) {
+		ref = strings.TrimSpace(ref)
+		if _, ok := seen[ref]; ref != "" && !ok {
+			seen[ref] = struct{}{}
+			refs = append(refs, ref)
+		}
+	}
+	addHTML := func(lines *text.Segments) {
+		var b strings.Builder
+		for i := 0; i < lines.Len(); i++ {
+			seg := lines.At(i)
+			b.Write(seg.Value(source))
+		}
+		for _, m := range imgSrcRe.FindAllStringSubmatch(b.String(), -1) {
+			add(m[1])
+		}
+	}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.CodeSpan:
+			return ast.WalkSkipChildren, nil
+		case *ast.Image:
+			add(string(t.Destination))
+		case *ast.HTMLBlock:
+			addHTML(t.Lines())
+		case *ast.RawHTML:
+			addHTML(t.Segments)
+		}
+		return ast.WalkContinue, nil
+	})
+	return refs
+}
+
+// ReplaceImageRefs 把正文中的图片地址按 urls（旧地址 → 新地址）替换，
+// 覆盖行内图片 ![](old)、引用定义 [id]: old 与 <img src="old">，不会误改以 old 为前缀的其他地址。
+func ReplaceImageRefs(src string, urls map[string]string) string {
+	for old, repl := range urls {
+		re := regexp.MustCompile(`(?m)(\]\(\s*<?|^\s*\[[^\]]+\]:[ \t]*<?|\bsrc\s*=\s*["']?)` + regexp.QuoteMeta(old) + `(>?[\s)"']|>?$)`)
+		src = re.ReplaceAllString(src, "${1}"+strings.ReplaceAll(repl, "$", "$$")+"${2}")
+	}
+	return src
+}
@@ -0,0 +1,110 @@
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// MaxLinkTitleLength [[Title]] 形式链接标题的最大字符数，超出的链接忽略
+const MaxLinkTitleLength = 255
+
+var (
+	// wikiLinkRe 匹配 [[12]]、[[Title]] 与 [[Title|显示文本]]
+	wikiLinkRe = regexp.MustCompile(`\[\[([^\[\]\n]+?)\]\]`)
+	// noteURLRe 匹配指向笔记的路径或 URL，如 /notes/12、/api/v1/notes/12、https://example.com/public/notes/12
+	noteURLRe = regexp.MustCompile(`(?:^|[^\w/.-])(?:https?://[\w.-]+(?::\d+)?)?(?:/api/v1)?(?:/public)?/notes/(\d+)\b`)
+)
+
+// LinkRef 正文中引用的一篇笔记：NoteID 非零表示按 ID 引用，否则 Title 为 [[Title]] 中的标题。
+type LinkRef struct {
+	NoteID uint
+	Title  string
+}
+
+// ExtractLinks 提取正文中对其他笔记的引用：[[note-id]]、[[Title]] 以及指向 /notes/:id 的链接或路径。
+// 代码块与行内代码中的内容不计入；结果按首次出现的顺序去重。
+func ExtractLinks(src string) []LinkRef {
+	source := []byte(src)
+	doc := textParser.Parse(text.NewReader(source))
+
+	// 把可见文本与链接地址拼接成一段，再统一用正则匹配
+	var b strings.Builder
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			if n.Type() == ast.TypeBlock {
+				b.WriteByte('\n')
+			}
+			return ast.WalkContinue, nil
+		}
+		switch t := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.CodeSpan:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			b.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				b.WriteByte('\n')
+			}
+		case *ast.String:
+			b.Write(t.Value)
+		case *ast.Link:
+			b.WriteString("\n" + string(t.Destination) + "\n")
+		case *ast.AutoLink:
+			b.WriteString("\n" + string(t.URL(source)) + "\n")
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	body := b.String()
+
+	var refs []LinkRef
+	seenID := make(map[uint]bool)
+	seenTitle := make(map[string]bool)
+	addID := func(s string) {
+		id, err := strconv.ParseUint(s, 10, 32)
+		if err != nil || id == 0 || seenID[uint(id)] {
+			return
+		}
+		seenID[uint(id)] = true
+		refs = append(refs, LinkRef{NoteID: uint(id)})
+	}
+
+	for _, m := range wikiLinkRe.FindAllStringSubmatch(body, -1) {
+		target := m[1]
+		if i := strings.Index(target, "|"); i >= 0 {
+			target = target[:i]
+		}
+		target = strings.TrimSpace(target)
+		if target == "" || utf8.RuneCountInString(target) > MaxLinkTitleLength {
+			continue
+		}
+		if isDigits(target) {
+			addID(target)
+			continue
+		}
+		key := strings.ToLower(target)
+		if seenTitle[key] {
+			continue
+		}
+		seenTitle[key] = true
+		refs = append(refs, LinkRef{Title: target})
+	}
+	for _, m := range noteURLRe.FindAllStringSubmatch(body, -1) {
+		addID(m[1])
+	}
+	return refs
+}
+
+// isDigits 判断字符串是否只由 ASCII 数字组成。
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,176 @@
+// Package markdown 将笔记的 Markdown 内容渲染为净化后的 HTML，并生成目录。
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"HYH-Blog-Gin/internal/models"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// HeadingAnchorClass 标题锚点链接的 class
+const HeadingAnchorClass = "heading-anchor"
+
+// tocKey 在解析上下文中保存目录（扁平列表）
+var tocKey = parser.NewContextKey()
+
+// md 使用 CommonMark + GFM（表格、删除线、自动链接、任务列表）解析，代码块由 chroma 以 CSS class 方式高亮。
+// 原始 HTML 会被保留，统一交由 policy 净化。goldmark 与 bluemonday 在构造后均可并发使用。
+var md = goldmark.New(
+	goldmark.WithExtensions(
+		extension.GFM,
+		highlighting.NewHighlighting(
+			highlighting.WithFormatOptions(chromahtml.WithClasses(true)),
+		),
+	),
+	goldmark.WithParserOptions(
+		parser.WithAutoHeadingID(),
+		parser.WithASTTransformers(util.Prioritized(headingTransformer{}, 100)),
+	),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+// policy 在 UGC 策略基础上放行代码高亮的 class、支持中文的标题 id 与任务列表复选框。
+var policy = func() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowStyling()
+	p.AllowAttrs("id").Matching(regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}()
+
+// Render 把 Markdown 渲染为净化后的 HTML，并返回按标题层级嵌套的目录。
+func Render(src string) (string, []models.TOCItem, error) {
+	source := []byte(src)
+	ctx := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+	var buf bytes.Buffer
+	if err := md.Convert(source, &buf, parser.WithContext(ctx)); err != nil {
+		return "", nil, fmt.Errorf("render markdown: %w", err)
+	}
+	flat, _ := ctx.Get(tocKey).([]models.TOCItem)
+	return policy.Sanitize(buf.String()), nestTOC(flat), nil
+}
+
+// headingTransformer 收集目录并为每个标题追加指向自身的锚点链接。
+type headingTransformer struct{}
+
+func (headingTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	var toc []models.TOCItem
+	source := reader.Source()
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		h, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		idAttr, _ := h.AttributeString("id")
+		id, _ := idAttr.([]byte)
+		if len(id) == 0 {
+			return ast.WalkSkipChildren, nil
+		}
+		var b strings.Builder
+		plainText(h, source, &b)
+		toc = append(toc, models.TOCItem{Level: h.Level, Text: strings.TrimSpace(b.String()), ID: string(id)})
+
+		link := ast.NewLink()
+		link.Destination = append([]byte("#"), id...)
+		link.SetAttributeString("class", []byte(HeadingAnchorClass))
+		link.AppendChild(link, ast.NewString([]byte("#")))
+		h.AppendChild(h, link)
+		return ast.WalkSkipChildren, nil
+	})
+	pc.Set(tocKey, toc)
+}
+
+// plainText 提取节点内的纯文本（忽略内联 HTML）。
+func plainText(n ast.Node, source []byte, b *strings.Builder) {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch t := c.(type) {
+		case *ast.Text:
+			b.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(t.Value)
+		case *ast.RawHTML:
+		default:
+			plainText(c, source, b)
+		}
+	}
+}
+
+// nestTOC 将扁平目录按标题层级嵌套：层级更深的后续标题成为前一个标题的子项。
+func nestTOC(flat []models.TOCItem) []models.TOCItem {
+	var out []models.TOCItem
+	for i := 0; i < len(flat); {
+		item := flat[i]
+		j := i + 1
+		for j < len(flat) && flat[j].Level > item.Level {
+			j++
+		}
+		item.Children = nestTOC(flat[i+1 : j])
+		out = append(out, item)
+		i = j
+	}
+	return out
+}
+
+// headingIDs 生成标题 id：保留各语言的字母与数字（含中文）并转为小写，空白折叠为连字符，重复时追加 -1、-2…
+type headingIDs struct {
+	used map[string]bool
+}
+
+func newHeadingIDs() parser.IDs {
+	return &headingIDs{used: map[string]bool{}}
+}
+
+func (s *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(string(value)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-':
+			dash = true
+		}
+	}
+	id := b.String()
+	if id == "" {
+		id = "heading"
+	}
+	if !s.used[id] {
+		s.used[id] = true
+		return []byte(id)
+	}
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", id, i)
+		if !s.used[candidate] {
+			s.used[candidate] = true
+			return []byte(candidate)
+		}
+	}
+}
+
+func (s *headingIDs) Put(value []byte) {
+	s.used[string(value)] = true
+}
@@ -0,0 +1,60 @@
+package markdown
+
+import (
+	"math"
+	"unicode"
+
+	"HYH-Blog-Gin/internal/utils"
+)
+
+// 阅读速度：中文约每分钟 400 字，英文约每分钟 200 词
+const (
+	cjkCharsPerMinute   = 400
+	latinWordsPerMinute = 200
+)
+
+// ReadingStats 正文的字数与预计阅读时间。
+type ReadingStats struct {
+	WordCount      int
+	ReadingMinutes int
+}
+
+// Reading 统计 Markdown 正文的字数与阅读时间，只统计 PlainText 保留的文字（不含代码块与 HTML）：
+// CJK 字符每个计为一个字，其余文字按词计数（字母或数字组成的连续片段，词内的 ' ’ - _ . 不拆分，如 don't、e-mail、3.14）。
+// 阅读时间按中英文各自的阅读速度相加后向上取整，有内容时至少为 1 分钟。
+func Reading(src string) ReadingStats {
+	var cjk, latin int
+	inWord := false
+	runes := []rune(PlainText(src))
+	for i, r := range runes {
+		switch {
+		case utils.IsCJK(r):
+			cjk++
+			inWord = false
+		case isWordRune(r) || unicode.IsMark(r) && inWord:
+			if !inWord {
+				latin++
+				inWord = true
+			}
+		case inWord && isWordJoiner(r) && i+1 < len(runes) && isWordRune(runes[i+1]):
+			// 连接符两侧都是字母或数字时仍属于同一个词
+		default:
+			inWord = false
+		}
+	}
+	stats := ReadingStats{WordCount: cjk + latin}
+	if stats.WordCount > 0 {
+		minutes := float64(cjk)/cjkCharsPerMinute + float64(latin)/latinWordsPerMinute
+		stats.ReadingMinutes = int(math.Max(1, math.Ceil(minutes)))
+	}
+	return stats
+}
+
+// isWordJoiner 判断字符是否为可以出现在词内部的连接符
+func isWordJoiner(r rune) bool {
+	switch r {
+	case '\'', '’', '-', '_', '.':
+		return true
+	}
+	return false
+}
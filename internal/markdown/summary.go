@@ -0,0 +1,88 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+
+	"HYH-Blog-Gin/internal/utils"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+// textParser 仅用于提取纯文本，不附加标题锚点等渲染用的 AST 变换。
+var textParser = goldmark.New(goldmark.WithExtensions(extension.GFM)).Parser()
+
+// PlainText 去除 Markdown 语法，返回正文纯文本：保留文字、链接文本与行内代码，
+// 图片取替代文本，忽略代码块与 HTML；块之间以空格分隔，连续空白折叠为一个空格。
+func PlainText(src string) string {
+	source := []byte(src)
+	doc := textParser.Parse(text.NewReader(source))
+	var b strings.Builder
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		switch t := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			if entering {
+				b.Write(t.Segment.Value(source))
+				if t.SoftLineBreak() || t.HardLineBreak() {
+					b.WriteByte(' ')
+				}
+			}
+		case *ast.String:
+			if entering {
+				b.Write(t.Value)
+			}
+		default:
+			if n.Type() == ast.TypeBlock && !entering {
+				b.WriteByte(' ')
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Summary 从 Markdown 正文生成不超过 maxRunes 个字符的纯文本摘要，超出时以省略号结尾。
+// 按字符（而非字节）计数；截断点落在英文单词中间时回退到保留部分中最后一个空格、标点或 CJK 字符之后，
+// 整段都是同一个单词时才在单词中间截断；CJK 文本可在任意字符处截断。
+func Summary(src string, maxRunes int) string {
+	runes := []rune(PlainText(src))
+	if maxRunes <= 0 || len(runes) <= maxRunes {
+		return string(runes)
+	}
+	cut := maxRunes
+	next := runes[cut]
+	if isWordRune(runes[cut-1]) && (isWordRune(next) || (isApostrophe(next) && cut+1 < len(runes) && isWordRune(runes[cut+1]))) {
+		for i := cut - 1; i > 0; i-- {
+			if utils.IsCJK(runes[i]) {
+				cut = i + 1
+				break
+			}
+			if isWordBreak(runes[i]) {
+				cut = i
+				break
+			}
+		}
+	}
+	return strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
+
+// isWordBreak 判断字符是否可以作为单词边界：空白或标点（撇号除外，如 don't）。
+func isWordBreak(r rune) bool {
+	return unicode.IsSpace(r) || (unicode.IsPunct(r) && !isApostrophe(r))
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
+
+// isWordRune 判断字符是否属于需要保持完整的单词（非 CJK 的字母或数字）。
+func isWordRune(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsDigit(r)) && !utils.IsCJK(r)
+}
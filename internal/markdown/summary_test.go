@@ -0,0 +1,29 @@
+package markdown
+
+import "testing"
+
+func TestSummaryCut(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		maxRunes int
+		want     string
+	}{
+		{"fits", "Hello world", 20, "Hello world"},
+		{"back off to space", "Hello world, don't stop.", 10, "Hello…"},
+		{"back off past apostrophe", "Hello world, don't stop.", 16, "Hello world…"},
+		{"back off to punctuation", "Hello,world again", 9, "Hello…"},
+		{"cut at word end", "Hello world again", 11, "Hello world…"},
+		{"single long word", "Supercalifragilistic", 5, "Super…"},
+		{"cjk anywhere", "你好世界，欢迎阅读", 3, "你好世…"},
+		{"latin after cjk", "介绍golang语言", 5, "介绍…"},
+		{"markdown stripped", "# Title\n\nSome **bold** text here", 15, "Title Some bold…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Summary(tt.src, tt.maxRunes); got != tt.want {
+				t.Errorf("Summary(%q, %d) = %q, want %q", tt.src, tt.maxRunes, got, tt.want)
+			}
+		})
+	}
+}
@@ -30,3 +30,29 @@ func AuthMiddleware(jwt *auth.JWTService) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalAuth 可选鉴权：未携带 Authorization 头时以匿名身份继续处理；
+// 携带了 Bearer Token 则按 AuthMiddleware 相同规则校验，无效时返回 401，避免静默降级为匿名。
+func OptionalAuth(jwt *auth.JWTService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authz := c.GetHeader("Authorization")
+		if authz == "" {
+			c.Next()
+			return
+		}
+		parts := strings.SplitN(authz, " ", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+			utils.Unauthorized(c, "invalid Authorization header")
+			c.Abort()
+			return
+		}
+		userID, err := jwt.ParseToken(parts[1])
+		if err != nil || userID == 0 {
+			utils.Unauthorized(c, "invalid or expired token")
+			c.Abort()
+			return
+		}
+		c.Set(config.ContextUserIDKey, userID)
+		c.Next()
+	}
+}
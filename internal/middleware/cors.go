@@ -32,7 +32,7 @@ func CORSMiddleware(allowedOrigins []string, allowCredentials bool) gin.HandlerF
 		}
 		c.Header("Vary", "Origin")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Requested-With, X-Request-Id")
+		c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type, X-Requested-With, X-Request-Id, X-Share-Password")
 		c.Header("Access-Control-Expose-Headers", "Content-Length, X-Request-Id")
 
 		// 仅当回显具体 Origin 且允许时才允许凭证
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Bookmark 用户收藏的笔记（阅读列表），每个用户对同一笔记最多收藏一次。
+// Folder 为可选的收藏夹名称（空字符串表示未分类），Annotation 为仅收藏者可见的私人批注。
+type Bookmark struct {
+	ID         uint      `json:"id" gorm:"primaryKey" example:"1"`
+	UserID     uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_bookmarks_user_note,priority:1;index:idx_bookmarks_user_folder,priority:1" example:"2"`
+	User       User      `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	NoteID     uint      `json:"note_id" gorm:"not null;uniqueIndex:idx_bookmarks_user_note,priority:2;index" example:"1"`
+	Note       *Note     `json:"note,omitempty" gorm:"foreignKey:NoteID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Folder     string    `json:"folder" gorm:"type:varchar(64);not null;default:'';index:idx_bookmarks_user_folder,priority:2" example:"to-read"`
+	Annotation string    `json:"annotation" gorm:"type:text;not null;default:''" example:"Revisit the caching section"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func (Bookmark) TableName() string { return "bookmarks" }
+
+// BookmarkFolder 收藏夹及其中的收藏数量，Name 为空表示未分类。
+type BookmarkFolder struct {
+	Name  string `json:"name" example:"to-read"`
+	Count int64  `json:"count" example:"3"`
+}
+
+// BookmarkRepository 收藏数据操作接口
+type BookmarkRepository interface {
+	Create(bookmark *Bookmark) error
+	// Find 查询用户对指定笔记的收藏，未收藏时返回 nil, nil
+	Find(userID, noteID uint) (*Bookmark, error)
+	Update(bookmark *Bookmark) error
+	// Delete 删除收藏，返回是否确有记录被删除
+	Delete(userID, noteID uint) (bool, error)
+	// List 按收藏时间倒序分页列出用户的收藏，folder 为 nil 表示不按收藏夹过滤；只包含用户仍可见的笔记
+	List(userID uint, folder *string, page, limit int) ([]Bookmark, int64, error)
+	// Folders 列出用户的收藏夹及数量
+	Folders(userID uint) ([]BookmarkFolder, error)
+	// BookmarkedNoteIDs 返回 noteIDs 中已被 userID 收藏的笔记集合
+	BookmarkedNoteIDs(userID uint, noteIDs []uint) (map[uint]bool, error)
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Comment 笔记评论，通过 ParentID 形成楼中楼回复。
+// RootID 指向所在讨论串的顶层评论（顶层评论为 nil），便于按讨论串一次性加载全部回复。
+// 删除为软删除：保留节点以维持回复结构，DeletedAt 非空时内容被清空。
+type Comment struct {
+	ID        uint       `json:"id" gorm:"primarykey" example:"1"`
+	NoteID    uint       `json:"note_id" gorm:"not null;index" example:"1"`
+	Note      Note       `json:"-" gorm:"foreignKey:NoteID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	AuthorID  uint       `json:"author_id" gorm:"not null;index" example:"2"`
+	Author    User       `json:"author" gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ParentID  *uint      `json:"parent_id" gorm:"index" example:"1"`
+	RootID    *uint      `json:"root_id" gorm:"index" example:"1"`
+	Content   string     `json:"content" gorm:"type:text;not null" example:"Nice post!"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	// Replies 直接回复，仅在按讨论串返回时由 service 组装
+	Replies []*Comment `json:"replies,omitempty" gorm:"-"`
+}
+
+func (Comment) TableName() string { return "comments" }
+
+// IsDeleted 判断评论是否已被删除。
+func (c *Comment) IsDeleted() bool { return c.DeletedAt != nil }
+
+// CommentRepository 评论数据操作接口
+type CommentRepository interface {
+	Create(comment *Comment) error
+	FindByID(id uint) (*Comment, error)
+	// ListRoots 按创建时间正序分页列出笔记的顶层评论；已删除且没有未删除回复的顶层评论不计入
+	ListRoots(noteID uint, page, limit int) ([]Comment, int64, error)
+	// ListByRoots 列出一组讨论串下的全部回复（含已删除），按创建时间正序
+	ListByRoots(rootIDs []uint) ([]Comment, error)
+	Update(comment *Comment) error
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"errors"
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -11,26 +14,185 @@ type Note struct {
 	Summary    string `json:"summary" gorm:"type:text" example:"A short summary"`
 	Content    string `json:"content" gorm:"type:text;not null" example:"Detailed content of the note..."`
 	CoverImage string `json:"cover_image" example:"/static/images/cover.webp"`
-	AuthorID   uint   `json:"author_id" gorm:"index" example:"1"`
+	AuthorID   uint   `json:"author_id" gorm:"index;uniqueIndex:idx_notes_author_slug,priority:1" example:"1"`
 	Author     User   `json:"author" gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	Tags       []Tag  `json:"tags" gorm:"many2many:note_tags;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	IsPublic   bool   `json:"is_public" gorm:"default:false;index" example:"true"`
-	Views      int64  `json:"views" gorm:"default:0" example:"123"`
-	Likes      int64  `json:"likes" gorm:"default:0" example:"10"`
+	// Unlisted 不公开列出：公开后凭链接可读，但不出现在公开列表、订阅源、站点地图、相关推荐、热度排行与反向链接中
+	Unlisted bool `json:"unlisted" gorm:"not null;default:false" example:"false"`
+	// Slug 由标题生成、在作者范围内唯一的永久链接片段，标题变更时自动更新，旧值记录在 note_slug_history 中
+	Slug string `json:"slug" gorm:"type:varchar(100);not null;default:'';uniqueIndex:idx_notes_author_slug,priority:2,where:slug <> ''" example:"hello-world"`
+	// Status 发布状态（draft/scheduled/published/unpublished），IsPublic 仅在 published 时为 true
+	Status      string     `json:"status" gorm:"type:varchar(16);not null;default:draft;index" example:"published"`
+	PublishAt   *time.Time `json:"publish_at,omitempty" gorm:"index"` // 定时发布时间（仅 scheduled）
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" gorm:"index"` // 到期自动下线时间
+	PublishedAt *time.Time `json:"published_at,omitempty"`            // 首次发布时间
+	// Version 乐观锁版本号，每次更新笔记内容或发布状态时加 1
+	Version int64 `json:"version" gorm:"not null;default:1" example:"3"`
+	Views   int64 `json:"views" gorm:"default:0" example:"123"`
+	Likes   int64 `json:"likes" gorm:"default:0" example:"10"`
+	// WordCount 正文字数（CJK 按字、其他文字按词计数），ReadingMinutes 预计阅读分钟数；由仓储在保存时根据 Content 计算
+	WordCount      int `json:"word_count" gorm:"not null;default:0" example:"1200"`
+	ReadingMinutes int `json:"reading_minutes" gorm:"not null;default:0" example:"4"`
+	// SearchVector 全文检索向量（标题 A、摘要 B、正文 C 加权），由仓储在写入时维护，不对外输出
+	SearchVector string `json:"-" gorm:"type:tsvector;->:false;<-:false;index:idx_notes_search_vector,type:gin"`
+	// Highlight 搜索结果中的高亮片段（已 HTML 转义，匹配处以 <mark> 包裹），仅在全文检索时填充
+	Highlight string `json:"highlight,omitempty" gorm:"->;-:migration"`
+	// ContentHTML 由 Content 渲染并净化后的 HTML，TOC 为标题目录；仅在读取单条笔记时填充，不落库
+	ContentHTML string    `json:"content_html,omitempty" gorm:"-"`
+	TOC         []TOCItem `json:"toc,omitempty" gorm:"-"`
+	// LikedByMe 当前请求者是否已点赞，仅在按请求者返回笔记时填充（匿名为 false）
+	LikedByMe *bool `json:"liked_by_me,omitempty" gorm:"-"`
+	// BookmarkedByMe 当前请求者是否已收藏，填充规则同 LikedByMe
+	BookmarkedByMe *bool `json:"bookmarked_by_me,omitempty" gorm:"-"`
+	// Series 笔记所属系列中的位置与相邻笔记，仅在读取单条笔记时填充
+	Series *NoteSeriesContext `json:"series,omitempty" gorm:"-"`
 }
 
 func (Note) TableName() string { return "notes" }
 
+// ErrNoteVersionConflict 更新时笔记的版本号与数据库中的不一致（已被其他请求修改）。
+var ErrNoteVersionConflict = errors.New("note has been modified by another request")
+
+// 笔记发布状态
+const (
+	NoteStatusDraft       = "draft"       // 草稿，仅作者可见
+	NoteStatusScheduled   = "scheduled"   // 定时发布，到达 PublishAt 后由后台任务发布
+	NoteStatusPublished   = "published"   // 已发布，公开可见
+	NoteStatusUnpublished = "unpublished" // 已下线（手动或到达 ExpiresAt），仅作者可见
+)
+
+// 笔记可见性（创建/更新笔记时的 visibility 字段），由 IsPublic 与 Unlisted 组合表示
+const (
+	NoteVisibilityPublic   = "public"   // 公开并出现在各类列表中
+	NoteVisibilityUnlisted = "unlisted" // 公开但不列出，仅凭链接可读
+	NoteVisibilityPrivate  = "private"  // 仅作者可见
+)
+
+// IsPubliclyVisible 判断笔记在 now 时刻是否对所有人可见：已公开且未过期。
+func (n *Note) IsPubliclyVisible(now time.Time) bool {
+	return n.IsPublic && (n.ExpiresAt == nil || n.ExpiresAt.After(now))
+}
+
+// TOCItem 笔记目录项，Children 为层级更深的下级标题。
+type TOCItem struct {
+	Level    int       `json:"level" example:"2"`
+	Text     string    `json:"text" example:"Getting started"`
+	ID       string    `json:"id" example:"getting-started"`
+	Children []TOCItem `json:"children,omitempty"`
+}
+
+// PublicNoteFilter 公开笔记列表的过滤条件，零值字段表示不按该条件过滤。
+type PublicNoteFilter struct {
+	AuthorID uint
+	Tag      string
+}
+
+// RelatedNote 相关阅读推荐中的一篇公开笔记。
+// SharedTags 为与源笔记共有的标签数，Jaccard 为两篇笔记标签集合的 Jaccard 相似度，Score 为综合标签、时间与热度的排序得分。
+type RelatedNote struct {
+	ID          uint       `json:"id" example:"7"`
+	Title       string     `json:"title" example:"Goroutine leaks"`
+	Slug        string     `json:"slug" example:"goroutine-leaks"`
+	Summary     string     `json:"summary" example:"A short summary"`
+	CoverImage  string     `json:"cover_image" example:"/static/images/cover.webp"`
+	AuthorID    uint       `json:"author_id" example:"1"`
+	Username    string     `json:"username" example:"alice"`
+	SharedTags  int        `json:"shared_tags" example:"2"`
+	Jaccard     float64    `json:"jaccard" example:"0.5"`
+	Views       int64      `json:"views" example:"123"`
+	Likes       int64      `json:"likes" example:"10"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Score       float64    `json:"score" example:"0.72"`
+}
+
+// 搜索结果排序方式
+const (
+	NoteSortRelevance = "relevance" // 相关度倒序（有关键字时的默认值）
+	NoteSortNewest    = "newest"    // 创建时间倒序（无关键字时的默认值）
+	NoteSortOldest    = "oldest"    // 创建时间正序
+	NoteSortUpdated   = "updated"   // 更新时间倒序
+	NoteSortViews     = "views"     // 阅读量倒序
+	NoteSortLikes     = "likes"     // 点赞数倒序
+)
+
+// NoteSearchOptions 笔记搜索条件，零值字段表示不按该条件过滤。
+type NoteSearchOptions struct {
+	Query    string     // 标题/内容关键字
+	Tags     []string   // 标签名
+	MatchAll bool       // true 表示必须包含全部标签，false 表示包含任一标签
+	Status   string     // 发布状态，为空表示不过滤
+	Sort     string     // 排序方式，见 NoteSort* 常量；为空时按是否有关键字选择默认值
+	From     *time.Time // 创建时间下界（含）
+	To       *time.Time // 创建时间上界（不含）
+}
+
+// 批量操作类型
+const (
+	NoteBulkDelete        = "delete"         // 移入回收站
+	NoteBulkRestore       = "restore"        // 从回收站恢复
+	NoteBulkSetVisibility = "set_visibility" // 公开/取消公开，语义同更新笔记时的 public 字段
+	NoteBulkAddTags       = "add_tags"       // 追加标签
+	NoteBulkRemoveTags    = "remove_tags"    // 按名称移除标签
+	NoteBulkReplaceTags   = "replace_tags"   // 替换标签集合（空集合表示清空）
+)
+
+// 批量操作中单篇笔记失败的原因
+const (
+	NoteBulkErrNotFound   = "not found"
+	NoteBulkErrForbidden  = "forbidden"
+	NoteBulkErrNotTrashed = "not in trash"
+)
+
+// NoteBulkOp 批量操作：Action 为 NoteBulk* 常量，IsPublic 仅用于 set_visibility，Tags 仅用于标签相关操作。
+type NoteBulkOp struct {
+	Action   string
+	IsPublic bool
+	Tags     []string
+}
+
+// NoteBulkResult 批量操作中单篇笔记的处理结果：OK 为 false 时 Error 说明原因，Changed 表示笔记是否确有变化。
+type NoteBulkResult struct {
+	ID      uint   `json:"id" example:"1"`
+	OK      bool   `json:"ok" example:"true"`
+	Changed bool   `json:"changed" example:"true"`
+	Error   string `json:"error,omitempty" example:"forbidden"`
+}
+
 // NoteRepository 笔记数据操作接口
 type NoteRepository interface {
 	Create(note *Note) error
 	CreateWithTags(note *Note, tagNames []string) error
 	FindByID(id uint) (*Note, error)
 	FindByAuthor(authorID uint, page, limit int) ([]Note, int64, error)
-	Search(authorID uint, query string, tags []string) ([]Note, error)
+	// EachByAuthor 按 ID 顺序分批读取作者的全部笔记（预加载 Tags），每批调用一次 fn，fn 返回错误时停止
+	EachByAuthor(authorID uint, batchSize int, fn func([]Note) error) error
+	FindPublic(filter PublicNoteFilter, page, limit int) ([]Note, int64, error)
+	FindPublicByID(id uint) (*Note, error)
+	// FindPublicByIDs 查询一组笔记中公开列出（不含 unlisted）且未过期的部分（顺序不保证），不存在或不可见的 ID 被忽略
+	FindPublicByIDs(ids []uint) ([]Note, error)
+	// Related 列出与笔记至少共有一个标签的其他公开列出的笔记，按标签 Jaccard 相似度倒序，最多 limit 篇
+	Related(noteID uint, limit int) ([]RelatedNote, error)
+	// FindBySlug 按作者用户名与 slug 查询笔记；slug 为历史值时返回其当前笔记（调用方通过比较 Slug 判断是否需要重定向）
+	FindBySlug(username, slug string) (*Note, error)
+	Search(authorID uint, opts NoteSearchOptions, page, limit int) ([]Note, int64, error)
+	// Version 查询笔记当前的版本号，笔记不存在或已删除时返回 gorm.ErrRecordNotFound
+	Version(id uint) (int64, error)
+	// Update/UpdateWithTags 仅在 note.Version 与数据库一致时保存，成功后 note.Version 加 1 并记录修订；否则返回 ErrNoteVersionConflict
 	Update(note *Note) error
 	UpdateWithTags(note *Note, tagNames []string) error
 	Delete(id uint) error
+	// FindTrashed 分页列出作者回收站中（已软删除）的笔记，按删除时间倒序
+	FindTrashed(authorID uint, page, limit int) ([]Note, int64, error)
+	// FindTrashedByID 查询回收站中的笔记，笔记不存在或未删除时返回 gorm.ErrRecordNotFound
+	FindTrashedByID(id uint) (*Note, error)
+	// Restore 从回收站恢复笔记；Purge 彻底删除回收站中的笔记及其关联数据
+	Restore(id uint) error
+	Purge(id uint) error
+	// Bulk 在单个事务中对作者的一组笔记执行批量操作，返回与 ids 顺序一致的逐条结果
+	Bulk(authorID uint, ids []uint, op NoteBulkOp) ([]NoteBulkResult, error)
 	AddTags(noteID uint, tags []Tag) error
 	RemoveTags(noteID uint, tagIDs []uint) error
 }
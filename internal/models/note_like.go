@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// NoteLike 用户对笔记的点赞记录，(NoteID, UserID) 为联合主键，保证每个用户对同一笔记最多点赞一次。
+// notes.likes 计数由后台计数同步任务按本表重新统计。
+type NoteLike struct {
+	NoteID    uint      `json:"note_id" gorm:"primaryKey;autoIncrement:false" example:"1"`
+	UserID    uint      `json:"user_id" gorm:"primaryKey;autoIncrement:false;index" example:"2"`
+	Note      Note      `json:"-" gorm:"foreignKey:NoteID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	User      User      `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (NoteLike) TableName() string { return "note_likes" }
+
+// NoteLikeRepository 点赞记录操作接口
+type NoteLikeRepository interface {
+	// Like 记录点赞，返回是否为新增（已点赞时返回 false）
+	Like(noteID, userID uint) (bool, error)
+	// Unlike 取消点赞，返回是否确有记录被删除
+	Unlike(noteID, userID uint) (bool, error)
+	// LikedNoteIDs 返回 noteIDs 中已被 userID 点赞的笔记集合
+	LikedNoteIDs(userID uint, noteIDs []uint) (map[uint]bool, error)
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// NoteLink 笔记正文中对其他笔记的引用（有向边）。
+// 按 ID 引用时 TargetID 为目标笔记 ID（目标可能已被删除或从未存在）；
+// 按 [[Title]] 引用时保存原始标题，写入时能在作者自己的笔记中找到同名笔记则同时记录 TargetID。
+type NoteLink struct {
+	ID          uint      `json:"id" gorm:"primaryKey" example:"1"`
+	SourceID    uint      `json:"source_id" gorm:"not null;index" example:"1"`
+	Source      *Note     `json:"-" gorm:"foreignKey:SourceID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	TargetID    *uint     `json:"target_id" gorm:"index" example:"2"`
+	TargetTitle string    `json:"target_title" gorm:"type:varchar(255);not null;default:''" example:"Getting started"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func (NoteLink) TableName() string { return "note_links" }
+
+// NoteLinkItem 出链或反向链接列表中的一篇笔记。
+// Exists 为 false 表示目标笔记已删除、不存在或 [[Title]] 找不到对应笔记；对请求者不可见的目标不返回标题与 slug。
+type NoteLinkItem struct {
+	NoteID    *uint      `json:"note_id" example:"2"`
+	Title     string     `json:"title" example:"Getting started"`
+	Slug      string     `json:"slug" example:"getting-started"`
+	Exists    bool       `json:"exists" example:"true"`
+	AuthorID  uint       `json:"-"`
+	IsPublic  bool       `json:"-"`
+	ExpiresAt *time.Time `json:"-"`
+}
+
+// IsPubliclyVisible 判断链接目标在 now 时刻是否对所有人可见，规则与 Note.IsPubliclyVisible 一致。
+func (i *NoteLinkItem) IsPubliclyVisible(now time.Time) bool {
+	return i.IsPublic && (i.ExpiresAt == nil || i.ExpiresAt.After(now))
+}
+
+// NoteLinkRepository 笔记链接查询接口；链接本身由 NoteRepository 在创建/更新笔记的事务中维护。
+type NoteLinkRepository interface {
+	// Outgoing 按正文中出现的顺序列出笔记的出链；[[Title]] 链接按源笔记作者的笔记标题（不区分大小写）解析
+	Outgoing(source *Note) ([]NoteLinkItem, error)
+	// Backlinks 列出引用 target 的未删除笔记中对 viewerID 可见的部分，按更新时间倒序
+	Backlinks(target *Note, viewerID uint) ([]NoteLinkItem, error)
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// NoteRevision 笔记修订记录：每次更新笔记时在同一事务内保存一份更新后的快照。
+// Version 在单个笔记内从 1 开始递增。
+type NoteRevision struct {
+	ID        uint      `json:"id" gorm:"primarykey" example:"1"`
+	NoteID    uint      `json:"note_id" gorm:"not null;uniqueIndex:idx_note_revisions_note_version,priority:1" example:"1"`
+	Version   int       `json:"version" gorm:"not null;uniqueIndex:idx_note_revisions_note_version,priority:2" example:"3"`
+	Title     string    `json:"title" gorm:"not null" example:"Hello world"`
+	Summary   string    `json:"summary" gorm:"type:text" example:"A short summary"`
+	Content   string    `json:"content" gorm:"type:text;not null" example:"Detailed content of the note..."`
+	TagNames  []string  `json:"tags" gorm:"type:jsonb;serializer:json"`
+	EditorID  uint      `json:"editor_id" gorm:"index" example:"1"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (NoteRevision) TableName() string { return "note_revisions" }
+
+// NoteRevisionRepository 笔记修订记录查询接口（写入由 NoteRepository 在更新事务内完成）
+type NoteRevisionRepository interface {
+	ListByNote(noteID uint, page, limit int) ([]NoteRevision, int64, error)
+	FindByVersion(noteID uint, version int) (*NoteRevision, error)
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// NoteShare 笔记的分享链接：持有 Token 的任何人无需登录即可读取笔记（包括未公开的笔记）。
+// 可选设置到期时间、访问密码（bcrypt 哈希）与最大访问次数；作者撤销（删除记录）后立即失效。
+type NoteShare struct {
+	ID           uint       `json:"id" gorm:"primaryKey" example:"1"`
+	NoteID       uint       `json:"note_id" gorm:"not null;index" example:"1"`
+	Note         *Note      `json:"-" gorm:"foreignKey:NoteID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Token        string     `json:"token" gorm:"type:varchar(64);not null;uniqueIndex" example:"3f9c2a7e5b1d4c8fa0e6b2d9c7a14e53"`
+	PasswordHash string     `json:"-" gorm:"type:varchar(100);not null;default:''"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	MaxViews     *int       `json:"max_views,omitempty" example:"10"`
+	Views        int        `json:"views" gorm:"not null;default:0" example:"3"`
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	// HasPassword 是否需要密码，由 PasswordHash 推导，不落库
+	HasPassword bool `json:"has_password" gorm:"-" example:"true"`
+}
+
+func (NoteShare) TableName() string { return "note_shares" }
+
+// IsExpired 判断分享链接在 now 时刻是否已到期或访问次数已用尽。
+func (s *NoteShare) IsExpired(now time.Time) bool {
+	if s.ExpiresAt != nil && !s.ExpiresAt.After(now) {
+		return true
+	}
+	return s.MaxViews != nil && s.Views >= *s.MaxViews
+}
+
+// NoteShareRepository 分享链接数据操作接口
+type NoteShareRepository interface {
+	Create(share *NoteShare) error
+	// FindByToken 按 Token 查询分享链接，不存在时返回 nil, nil
+	FindByToken(token string) (*NoteShare, error)
+	// ListByNote 按创建时间倒序列出笔记的全部分享链接（含已到期的）
+	ListByNote(noteID uint) ([]NoteShare, error)
+	// Delete 删除笔记下的分享链接，返回是否确有记录被删除
+	Delete(noteID, shareID uint) (bool, error)
+	// Consume 原子地记录一次访问；链接已到期或访问次数已用尽时返回 false
+	Consume(id uint) (bool, error)
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// NoteSlugHistory 笔记的历史 slug：标题变更导致 slug 变化时记录旧值，
+// 使旧的永久链接仍能解析并 301 重定向到当前 slug。(AuthorID, Slug) 唯一。
+type NoteSlugHistory struct {
+	ID        uint      `json:"id" gorm:"primarykey" example:"1"`
+	NoteID    uint      `json:"note_id" gorm:"not null;index" example:"1"`
+	AuthorID  uint      `json:"author_id" gorm:"not null;uniqueIndex:idx_note_slug_history_author_slug,priority:1" example:"1"`
+	Slug      string    `json:"slug" gorm:"type:varchar(100);not null;uniqueIndex:idx_note_slug_history_author_slug,priority:2" example:"old-title"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (NoteSlugHistory) TableName() string { return "note_slug_history" }
@@ -0,0 +1,83 @@
+package models
+
+import "time"
+
+// Series 作者维护的笔记系列（如多篇连载教程），成员笔记按 series_notes.position 排序。
+// 系列至少包含一篇笔记且全部成员笔记公开列出、未过期时，对所有人可见。
+type Series struct {
+	ID          uint   `json:"id" gorm:"primaryKey" example:"1"`
+	AuthorID    uint   `json:"author_id" gorm:"not null;index" example:"1"`
+	Author      User   `json:"author" gorm:"foreignKey:AuthorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Title       string `json:"title" gorm:"type:varchar(200);not null" example:"Building a blog with Gin"`
+	Description string `json:"description" gorm:"type:text;not null;default:''" example:"A step-by-step tutorial"`
+	// NoteCount 成员笔记数量，仅在列表查询时填充
+	NoteCount int64 `json:"note_count" gorm:"->;-:migration" example:"3"`
+	// Notes 按顺序排列的成员笔记，仅在读取单个系列时填充
+	Notes     []SeriesItem `json:"notes,omitempty" gorm:"-"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+func (Series) TableName() string { return "series" }
+
+// SeriesNote 系列成员关系，每篇笔记最多属于一个系列。
+type SeriesNote struct {
+	SeriesID uint    `json:"series_id" gorm:"primaryKey;autoIncrement:false" example:"1"`
+	Series   *Series `json:"-" gorm:"foreignKey:SeriesID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	NoteID   uint    `json:"note_id" gorm:"primaryKey;autoIncrement:false;uniqueIndex" example:"1"`
+	Note     *Note   `json:"-" gorm:"foreignKey:NoteID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Position int     `json:"position" gorm:"not null" example:"1"`
+}
+
+func (SeriesNote) TableName() string { return "series_notes" }
+
+// SeriesItem 系列中的一篇笔记（只包含导航所需的字段）。
+type SeriesItem struct {
+	NoteID    uint       `json:"note_id" example:"1"`
+	Title     string     `json:"title" example:"Part 1: Setup"`
+	Slug      string     `json:"slug" example:"part-1-setup"`
+	Status    string     `json:"status" example:"published"`
+	IsPublic  bool       `json:"is_public" example:"true"`
+	Unlisted  bool       `json:"unlisted" example:"false"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// IsPubliclyVisible 判断该成员笔记在 now 时刻是否对所有人可见，规则与 Note.IsPubliclyVisible 一致。
+func (i *SeriesItem) IsPubliclyVisible(now time.Time) bool {
+	return i.IsPublic && (i.ExpiresAt == nil || i.ExpiresAt.After(now))
+}
+
+// IsListed 判断该成员笔记在 now 时刻是否可向非作者列出：公开未过期且不是“不公开列出”的笔记。
+func (i *SeriesItem) IsListed(now time.Time) bool {
+	return i.IsPubliclyVisible(now) && !i.Unlisted
+}
+
+// NoteSeriesContext 笔记在所属系列中的位置：Position 从 1 开始，Prev/Next 为相邻的笔记（不存在时为空）。
+// 对非作者只统计可列出的成员笔记（不公开列出的笔记不计入，也不作为 Prev/Next）。
+type NoteSeriesContext struct {
+	ID       uint        `json:"id" example:"1"`
+	Title    string      `json:"title" example:"Building a blog with Gin"`
+	Position int         `json:"position" example:"2"`
+	Total    int         `json:"total" example:"3"`
+	Prev     *SeriesItem `json:"prev,omitempty"`
+	Next     *SeriesItem `json:"next,omitempty"`
+}
+
+// SeriesRepository 系列数据操作接口
+type SeriesRepository interface {
+	Create(series *Series) error
+	FindByID(id uint) (*Series, error)
+	// FindByNote 查询笔记所属的系列，笔记不属于任何系列时返回 nil, nil
+	FindByNote(noteID uint) (*Series, error)
+	// FindByAuthor 按更新时间倒序分页列出作者的全部系列
+	FindByAuthor(authorID uint, page, limit int) ([]Series, int64, error)
+	// FindPublic 按更新时间倒序分页列出公开系列（至少一篇成员笔记且全部公开列出、未过期），authorID 为 0 表示不按作者过滤
+	FindPublic(authorID uint, page, limit int) ([]Series, int64, error)
+	// Items 按顺序列出系列的成员笔记（不含已删除的笔记）
+	Items(seriesID uint) ([]SeriesItem, error)
+	// SetNotes 以 noteIDs 的顺序整体替换系列的成员笔记
+	SetNotes(seriesID uint, noteIDs []uint) error
+	Update(series *Series) error
+	// Delete 删除系列及其成员关系，成员笔记本身不受影响
+	Delete(id uint) error
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// SitemapNote 站点地图中一篇公开笔记所需的字段。
+type SitemapNote struct {
+	ID        uint
+	Slug      string
+	Username  string
+	UpdatedAt time.Time
+}
+
+// SitemapGroup 站点地图中的作者或标签页面；UpdatedAt 为其下公开笔记中最近的更新时间。
+// 作者页面 ID 为用户 ID、Name 为用户名；标签页面 Name 为标签名。
+type SitemapGroup struct {
+	ID        uint
+	Name      string
+	UpdatedAt time.Time
+}
+
+// SitemapRepository 站点地图数据查询接口，只包含公开、未过期且未删除的笔记。
+type SitemapRepository interface {
+	// EachPublicNote 按 ID 升序以键集分页遍历公开笔记，每批最多 batchSize 条；fn 返回错误时停止遍历并返回该错误
+	EachPublicNote(batchSize int, fn func([]SitemapNote) error) error
+	// PublicAuthors 列出拥有公开笔记的作者，按用户 ID 升序
+	PublicAuthors() ([]SitemapGroup, error)
+	// PublicTags 列出至少包含一篇公开笔记的标签，按标签名升序
+	PublicTags() ([]SitemapGroup, error)
+}
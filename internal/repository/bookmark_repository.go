@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"errors"
+
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// 保证实现关系：若接口变更将在编译期报错
+var _ models.BookmarkRepository = (*bookmarkRepository)(nil)
+
+// bookmarkRepository 提供 BookmarkRepository 接口的 GORM 实现。
+type bookmarkRepository struct{ db *gorm.DB }
+
+// NewBookmarkRepository 构造基于 GORM 的收藏仓储实现。
+func NewBookmarkRepository(db *gorm.DB) models.BookmarkRepository {
+	return &bookmarkRepository{db: db}
+}
+
+// Create 新建收藏。
+func (r *bookmarkRepository) Create(bookmark *models.Bookmark) error {
+	return r.db.Omit("User", "Note").Create(bookmark).Error
+}
+
+// Find 查询用户对指定笔记的收藏，未收藏时返回 nil, nil。
+func (r *bookmarkRepository) Find(userID, noteID uint) (*models.Bookmark, error) {
+	var b models.Bookmark
+	err := r.db.Where("user_id = ? AND note_id = ?", userID, noteID).First(&b).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Update 保存收藏夹与批注。
+func (r *bookmarkRepository) Update(bookmark *models.Bookmark) error {
+	return r.db.Model(bookmark).Select("folder", "annotation", "updated_at").Updates(bookmark).Error
+}
+
+// Delete 删除收藏；通过影响行数判断是否确有记录被删除。
+func (r *bookmarkRepository) Delete(userID, noteID uint) (bool, error) {
+	res := r.db.Where("user_id = ? AND note_id = ?", userID, noteID).Delete(&models.Bookmark{})
+	return res.RowsAffected == 1, res.Error
+}
+
+// visible 构造用户仍可见的收藏查询：笔记未删除，且为自己的笔记或公开未过期的笔记。
+func (r *bookmarkRepository) visible(userID uint) *gorm.DB {
+	return r.db.Model(&models.Bookmark{}).
+		Joins("JOIN notes ON notes.id = bookmarks.note_id AND notes.deleted_at IS NULL").
+		Where("bookmarks.user_id = ?", userID).
+		Where("(notes.author_id = ? OR (notes.is_public = ? AND (notes.expires_at IS NULL OR notes.expires_at > now())))", userID, true)
+}
+
+// List 按收藏时间倒序分页列出收藏，并预加载笔记及其作者、标签；当 limit<=0 时，不应用分页（返回全部）。
+// 笔记被删除、取消公开或过期（且不是自己的笔记）后不再出现在列表中。
+func (r *bookmarkRepository) List(userID uint, folder *string, page, limit int) ([]models.Bookmark, int64, error) {
+	var bookmarks []models.Bookmark
+	var total int64
+
+	base := func() *gorm.DB {
+		q := r.visible(userID)
+		if folder != nil {
+			q = q.Where("bookmarks.folder = ?", *folder)
+		}
+		return q
+	}
+	if err := base().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	db := base().Preload("Note").Preload("Note.Author", publicAuthor).Preload("Note.Tags").
+		Order("bookmarks.created_at DESC, bookmarks.id DESC")
+	if limit > 0 {
+		if page <= 0 {
+			page = 1
+		}
+		db = db.Offset((page - 1) * limit).Limit(limit)
+	}
+	err := db.Find(&bookmarks).Error
+	return bookmarks, total, err
+}
+
+// Folders 按名称列出用户的收藏夹及收藏数量，计数口径与 List 一致。
+func (r *bookmarkRepository) Folders(userID uint) ([]models.BookmarkFolder, error) {
+	var folders []models.BookmarkFolder
+	err := r.visible(userID).
+		Select("bookmarks.folder AS name, COUNT(*) AS count").
+		Group("bookmarks.folder").
+		Order("bookmarks.folder ASC").
+		Scan(&folders).Error
+	return folders, err
+}
+
+// BookmarkedNoteIDs 批量查询用户已收藏的笔记。
+func (r *bookmarkRepository) BookmarkedNoteIDs(userID uint, noteIDs []uint) (map[uint]bool, error) {
+	marked := make(map[uint]bool, len(noteIDs))
+	if userID == 0 || len(noteIDs) == 0 {
+		return marked, nil
+	}
+	var ids []uint
+	if err := r.db.Model(&models.Bookmark{}).Where("user_id = ? AND note_id IN ?", userID, noteIDs).
+		Pluck("note_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		marked[id] = true
+	}
+	return marked, nil
+}
@@ -6,13 +6,19 @@ import (
 	"time"
 
 	"HYH-Blog-Gin/internal/cache"
+	"HYH-Blog-Gin/internal/markdown"
 	"HYH-Blog-Gin/internal/models"
 )
 
 // cachedNoteRepository 是 NoteRepository 的包装，提供基于 key 的 Redis 缓存（只用于 FindByID 的示例）。
 // 缓存策略：FindByID 读取缓存（JSON），缓存未命中则回退到底层仓储并填充缓存。
-// 所有会改变单个笔记数据的写操作（Create/Update/Delete/AddTags/RemoveTags/CreateWithTags/UpdateWithTags）在成功后都会失效对应 key。
+// 所有会改变单个笔记数据的写操作（Create/Update/Delete/Restore/Purge/Bulk/AddTags/RemoveTags/CreateWithTags/UpdateWithTags）在成功后都会失效对应 key，并递增公开内容缓存代数（订阅源与站点地图随之失效）。
 // TTL 可配置（构造时传入）。
+// 单条读取（FindByID/FindPublicByID/FindBySlug）与创建/更新后的笔记会把 Content 渲染为 ContentHTML 与 TOC：
+// FindByID 的结果连同渲染内容一起缓存；渲染结果另以 UpdatedAt 为版本单独缓存，供不走笔记缓存的公开读取复用。
+// 命中缓存时会用一次只查版本号的主键查询校验 Version，与数据库不一致（包括并发读写导致的旧数据回填）即丢弃缓存，
+// 保证返回的笔记不会比数据库旧。
+// Related 的候选列表按笔记与公开内容缓存代数缓存：任一笔记变化（包括候选笔记被取消公开、不公开列出或删除）递增代数后即失效。
 
 type cachedNoteRepository struct {
 	base  models.NoteRepository
@@ -25,13 +31,52 @@ func NewCachedNoteRepository(base models.NoteRepository, c cache.Cache, ttl time
 	return &cachedNoteRepository{base: base, cache: c, ttl: ttl}
 }
 
+// renderedNote 缓存的渲染结果，UpdatedAt 用于判断是否与笔记当前内容一致。
+type renderedNote struct {
+	HTML      string           `json:"html"`
+	TOC       []models.TOCItem `json:"toc"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// render 为笔记填充 ContentHTML 与 TOC，优先使用缓存中版本一致的渲染结果；渲染失败时仅返回原始 Content。
+func (r *cachedNoteRepository) render(note *models.Note) {
+	ctx := context.Background()
+	key := cache.NewKeyGenerator().NoteRendered(note.ID)
+	var cached renderedNote
+	if ok, err := r.cache.Get(ctx, key, &cached); err == nil && ok && sameVersion(cached.UpdatedAt, note.UpdatedAt) {
+		note.ContentHTML, note.TOC = cached.HTML, cached.TOC
+		return
+	}
+	html, toc, err := markdown.Render(note.Content)
+	if err != nil {
+		return
+	}
+	note.ContentHTML, note.TOC = html, toc
+	_ = r.cache.Set(ctx, key, renderedNote{HTML: html, TOC: toc, UpdatedAt: note.UpdatedAt}, r.ttl)
+}
+
+// sameVersion 比较两个更新时间；数据库只保存到微秒，写入后内存中的纳秒时间需截断后再比较。
+func sameVersion(a, b time.Time) bool {
+	return a.Truncate(time.Microsecond).Equal(b.Truncate(time.Microsecond))
+}
+
+// invalidate 清除笔记及其渲染结果与相关笔记的缓存，并使全部订阅源与站点地图缓存失效。
+func (r *cachedNoteRepository) invalidate(id uint) {
+	ctx := context.Background()
+	kg := cache.NewKeyGenerator()
+	_ = r.cache.Delete(ctx, kg.Note(id))
+	_ = r.cache.Delete(ctx, kg.NoteRendered(id))
+	_, _ = r.cache.Increment(ctx, cache.PublicGenerationKey, 1)
+}
+
 // Create 调用底层创建并在成功后清除缓存（如果有）。
 func (r *cachedNoteRepository) Create(note *models.Note) error {
 	if err := r.base.Create(note); err != nil {
 		return err
 	}
 	// 删除可能存在的旧缓存（新创建通常不会存在，但保险起见）
-	_ = r.cache.Delete(context.Background(), cache.NewKeyGenerator().Note(note.ID))
+	r.invalidate(note.ID)
+	r.render(note)
 	return nil
 }
 
@@ -39,7 +84,8 @@ func (r *cachedNoteRepository) CreateWithTags(note *models.Note, tagNames []stri
 	if err := r.base.CreateWithTags(note, tagNames); err != nil {
 		return err
 	}
-	_ = r.cache.Delete(context.Background(), cache.NewKeyGenerator().Note(note.ID))
+	r.invalidate(note.ID)
+	r.render(note)
 	return nil
 }
 
@@ -50,16 +96,26 @@ func (r *cachedNoteRepository) FindByID(id uint) (*models.Note, error) {
 	ok, err := r.cache.Get(ctx, key, &note)
 	if err != nil {
 		// 若缓存读取错误，不阻断，回退到 DB
-		return r.base.FindByID(id)
+		n, err := r.base.FindByID(id)
+		if err == nil {
+			r.render(n)
+		}
+		return n, err
 	}
 	if ok {
-		return &note, nil
+		current, verr := r.base.Version(id)
+		if verr == nil && current == note.Version {
+			return &note, nil
+		}
+		// 版本不一致或笔记已删除：丢弃缓存，以数据库为准
+		r.invalidate(id)
 	}
 	// 缓存未命中
 	n, err := r.base.FindByID(id)
 	if err != nil {
 		return n, err
 	}
+	r.render(n)
 	// 尝试异步写入缓存：若失败不影响返回（同步写入更简单但可能影响延迟）
 	_ = r.cache.Set(ctx, key, n, r.ttl)
 	return n, nil
@@ -69,15 +125,73 @@ func (r *cachedNoteRepository) FindByAuthor(authorID uint, page, limit int) ([]m
 	return r.base.FindByAuthor(authorID, page, limit)
 }
 
-func (r *cachedNoteRepository) Search(authorID uint, query string, tags []string) ([]models.Note, error) {
-	return r.base.Search(authorID, query, tags)
+func (r *cachedNoteRepository) EachByAuthor(authorID uint, batchSize int, fn func([]models.Note) error) error {
+	return r.base.EachByAuthor(authorID, batchSize, fn)
+}
+
+func (r *cachedNoteRepository) FindPublic(filter models.PublicNoteFilter, page, limit int) ([]models.Note, int64, error) {
+	return r.base.FindPublic(filter, page, limit)
+}
+
+// relatedNotes 缓存的相关笔记候选列表，Limit 不同视为未命中。
+type relatedNotes struct {
+	Limit int                  `json:"limit"`
+	Notes []models.RelatedNote `json:"notes"`
+}
+
+// Related 读取缓存的候选列表，未命中时查询并写入缓存。
+func (r *cachedNoteRepository) Related(noteID uint, limit int) ([]models.RelatedNote, error) {
+	ctx := context.Background()
+	gen, _ := r.cache.GetInteger(ctx, cache.PublicGenerationKey)
+	key := cache.NewKeyGenerator().NoteRelated(gen, noteID)
+	var cached relatedNotes
+	if ok, err := r.cache.Get(ctx, key, &cached); err == nil && ok && cached.Limit == limit {
+		return cached.Notes, nil
+	}
+	notes, err := r.base.Related(noteID, limit)
+	if err != nil {
+		return nil, err
+	}
+	_ = r.cache.Set(ctx, key, relatedNotes{Limit: limit, Notes: notes}, r.ttl)
+	return notes, nil
+}
+
+func (r *cachedNoteRepository) FindPublicByIDs(ids []uint) ([]models.Note, error) {
+	return r.base.FindPublicByIDs(ids)
+}
+
+// FindPublicByID 不走 FindByID 的缓存：缓存中的作者信息包含邮箱，不适合直接用于公开接口。
+func (r *cachedNoteRepository) FindPublicByID(id uint) (*models.Note, error) {
+	n, err := r.base.FindPublicByID(id)
+	if err == nil {
+		r.render(n)
+	}
+	return n, err
+}
+
+// FindBySlug 与 FindPublicByID 相同，不走缓存。
+func (r *cachedNoteRepository) FindBySlug(username, slug string) (*models.Note, error) {
+	n, err := r.base.FindBySlug(username, slug)
+	if err == nil {
+		r.render(n)
+	}
+	return n, err
+}
+
+func (r *cachedNoteRepository) Search(authorID uint, opts models.NoteSearchOptions, page, limit int) ([]models.Note, int64, error) {
+	return r.base.Search(authorID, opts, page, limit)
+}
+
+func (r *cachedNoteRepository) Version(id uint) (int64, error) {
+	return r.base.Version(id)
 }
 
 func (r *cachedNoteRepository) Update(note *models.Note) error {
 	if err := r.base.Update(note); err != nil {
 		return err
 	}
-	_ = r.cache.Delete(context.Background(), cache.NewKeyGenerator().Note(note.ID))
+	r.invalidate(note.ID)
+	r.render(note)
 	return nil
 }
 
@@ -85,7 +199,8 @@ func (r *cachedNoteRepository) UpdateWithTags(note *models.Note, tagNames []stri
 	if err := r.base.UpdateWithTags(note, tagNames); err != nil {
 		return err
 	}
-	_ = r.cache.Delete(context.Background(), cache.NewKeyGenerator().Note(note.ID))
+	r.invalidate(note.ID)
+	r.render(note)
 	return nil
 }
 
@@ -93,15 +208,57 @@ func (r *cachedNoteRepository) Delete(id uint) error {
 	if err := r.base.Delete(id); err != nil {
 		return err
 	}
-	_ = r.cache.Delete(context.Background(), cache.NewKeyGenerator().Note(id))
+	r.invalidate(id)
+	return nil
+}
+
+func (r *cachedNoteRepository) FindTrashed(authorID uint, page, limit int) ([]models.Note, int64, error) {
+	return r.base.FindTrashed(authorID, page, limit)
+}
+
+func (r *cachedNoteRepository) FindTrashedByID(id uint) (*models.Note, error) {
+	return r.base.FindTrashedByID(id)
+}
+
+func (r *cachedNoteRepository) Restore(id uint) error {
+	if err := r.base.Restore(id); err != nil {
+		return err
+	}
+	r.invalidate(id)
+	return nil
+}
+
+// Purge 彻底删除后清理该笔记的全部缓存键，包括尚未同步的阅读/点赞计数。
+func (r *cachedNoteRepository) Purge(id uint) error {
+	if err := r.base.Purge(id); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	for _, key := range cache.NewKeyGenerator().NoteAll(id) {
+		_ = r.cache.Delete(ctx, key)
+	}
 	return nil
 }
 
+// Bulk 批量操作成功后失效每篇被处理笔记的缓存。
+func (r *cachedNoteRepository) Bulk(authorID uint, ids []uint, op models.NoteBulkOp) ([]models.NoteBulkResult, error) {
+	results, err := r.base.Bulk(authorID, ids, op)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range results {
+		if res.OK {
+			r.invalidate(res.ID)
+		}
+	}
+	return results, nil
+}
+
 func (r *cachedNoteRepository) AddTags(noteID uint, tags []models.Tag) error {
 	if err := r.base.AddTags(noteID, tags); err != nil {
 		return err
 	}
-	_ = r.cache.Delete(context.Background(), cache.NewKeyGenerator().Note(noteID))
+	r.invalidate(noteID)
 	return nil
 }
 
@@ -109,6 +266,6 @@ func (r *cachedNoteRepository) RemoveTags(noteID uint, tagIDs []uint) error {
 	if err := r.base.RemoveTags(noteID, tagIDs); err != nil {
 		return err
 	}
-	_ = r.cache.Delete(context.Background(), cache.NewKeyGenerator().Note(noteID))
+	r.invalidate(noteID)
 	return nil
 }
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// 保证实现关系：若接口变更将在编译期报错
+var _ models.CommentRepository = (*commentRepository)(nil)
+
+// commentRepository 提供 CommentRepository 接口的 GORM 实现。
+// 评论的作者只预加载公开字段（id、username），避免在公开接口中泄露邮箱。
+type commentRepository struct{ db *gorm.DB }
+
+// NewCommentRepository 构造基于 GORM 的评论仓储实现。
+func NewCommentRepository(db *gorm.DB) models.CommentRepository {
+	return &commentRepository{db: db}
+}
+
+// Create 新建评论，顶层评论的 RootID 为空，回复沿用父评论所在讨论串。
+func (r *commentRepository) Create(comment *models.Comment) error {
+	if err := r.db.Omit("Note", "Author").Create(comment).Error; err != nil {
+		return err
+	}
+	return r.db.Preload("Author", publicAuthor).First(comment, "id = ?", comment.ID).Error
+}
+
+// FindByID 根据主键查询评论（含已删除），预加载作者。
+func (r *commentRepository) FindByID(id uint) (*models.Comment, error) {
+	var c models.Comment
+	err := r.db.Preload("Author", publicAuthor).First(&c, "id = ?", id).Error
+	return &c, err
+}
+
+// ListRoots 按创建时间正序分页列出顶层评论；当 limit<=0 时，不应用分页（返回全部）。
+func (r *commentRepository) ListRoots(noteID uint, page, limit int) ([]models.Comment, int64, error) {
+	var roots []models.Comment
+	var total int64
+
+	base := func() *gorm.DB {
+		return r.db.Model(&models.Comment{}).
+			Where("comments.note_id = ? AND comments.parent_id IS NULL", noteID).
+			Where("(comments.deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments rc WHERE rc.root_id = comments.id AND rc.deleted_at IS NULL))")
+	}
+	if err := base().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	db := base().Preload("Author", publicAuthor).Order("comments.created_at ASC, comments.id ASC")
+	if limit > 0 {
+		if page <= 0 {
+			page = 1
+		}
+		db = db.Offset((page - 1) * limit).Limit(limit)
+	}
+	err := db.Find(&roots).Error
+	return roots, total, err
+}
+
+// ListByRoots 列出指定讨论串下的全部回复，按创建时间正序。
+func (r *commentRepository) ListByRoots(rootIDs []uint) ([]models.Comment, error) {
+	var replies []models.Comment
+	if len(rootIDs) == 0 {
+		return replies, nil
+	}
+	err := r.db.Preload("Author", publicAuthor).
+		Where("root_id IN ?", rootIDs).
+		Order("created_at ASC, id ASC").
+		Find(&replies).Error
+	return replies, err
+}
+
+// Update 保存评论的内容与编辑/删除时间。
+func (r *commentRepository) Update(comment *models.Comment) error {
+	return r.db.Model(comment).Select("content", "edited_at", "deleted_at", "updated_at").Updates(comment).Error
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"time"
+
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Bulk 在单个事务中对作者的一组笔记执行批量操作：
+// - 以 FOR UPDATE 锁定 ids 对应的笔记（含回收站中的笔记），逐条校验存在性与归属，未通过的笔记记录失败原因并跳过；
+// - 对通过校验的笔记执行操作，公开状态或标签发生变化时递增乐观锁版本号，标签变化同时记录修订历史；
+// - 任一数据库错误都会回滚整个批次并返回错误。
+func (r *noteRepository) Bulk(authorID uint, ids []uint, op models.NoteBulkOp) ([]models.NoteBulkResult, error) {
+	results := make([]models.NoteBulkResult, len(ids))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		q := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"})
+		if isBulkTagAction(op.Action) {
+			q = q.Preload("Tags")
+		}
+		var notes []models.Note
+		if err := q.Where("id IN ?", ids).Find(&notes).Error; err != nil {
+			return err
+		}
+		byID := make(map[uint]*models.Note, len(notes))
+		for i := range notes {
+			byID[notes[i].ID] = &notes[i]
+		}
+
+		// targets 为通过校验的结果下标
+		var targets []int
+		for i, id := range ids {
+			results[i].ID = id
+			note, ok := byID[id]
+			switch {
+			case !ok:
+				results[i].Error = models.NoteBulkErrNotFound
+			case note.AuthorID != authorID:
+				results[i].Error = models.NoteBulkErrForbidden
+			case op.Action == models.NoteBulkRestore && !note.DeletedAt.Valid:
+				results[i].Error = models.NoteBulkErrNotTrashed
+			case op.Action != models.NoteBulkRestore && note.DeletedAt.Valid:
+				results[i].Error = models.NoteBulkErrNotFound
+			default:
+				results[i].OK = true
+				targets = append(targets, i)
+			}
+		}
+		if len(targets) == 0 {
+			return nil
+		}
+
+		switch op.Action {
+		case models.NoteBulkDelete, models.NoteBulkRestore:
+			targetIDs := make([]uint, 0, len(targets))
+			for _, i := range targets {
+				targetIDs = append(targetIDs, ids[i])
+				results[i].Changed = true
+			}
+			if op.Action == models.NoteBulkDelete {
+				return bulkDelete(tx, targetIDs)
+			}
+			return tx.Unscoped().Model(&models.Note{}).Where("id IN ?", targetIDs).
+				UpdateColumns(map[string]interface{}{
+					"deleted_at": nil,
+					"version":    gorm.Expr("version + 1"),
+					"updated_at": gorm.Expr("now()"),
+				}).Error
+		case models.NoteBulkSetVisibility:
+			now := time.Now()
+			for _, i := range targets {
+				cols := visibilityColumns(byID[ids[i]], op.IsPublic, now)
+				if cols == nil {
+					continue
+				}
+				if err := bumpNoteVersion(tx, ids[i], cols); err != nil {
+					return err
+				}
+				results[i].Changed = true
+			}
+		default:
+			for _, i := range targets {
+				changed, err := bulkUpdateTags(tx, byID[ids[i]], op)
+				if err != nil {
+					return err
+				}
+				results[i].Changed = changed
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// isBulkTagAction 判断批量操作是否涉及标签。
+func isBulkTagAction(action string) bool {
+	return action == models.NoteBulkAddTags || action == models.NoteBulkRemoveTags || action == models.NoteBulkReplaceTags
+}
+
+// bulkDelete 软删除一组笔记，并与单条删除一样清理收藏与系列成员关系。
+func bulkDelete(tx *gorm.DB, ids []uint) error {
+	if err := tx.Where("note_id IN ?", ids).Delete(&models.Bookmark{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("note_id IN ?", ids).Delete(&models.SeriesNote{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(&models.Note{}, "id IN ?", ids).Error
+}
+
+// bumpNoteVersion 更新笔记的部分列，同时递增乐观锁版本号并刷新更新时间。
+func bumpNoteVersion(tx *gorm.DB, id uint, cols map[string]interface{}) error {
+	cols["version"] = gorm.Expr("version + 1")
+	cols["updated_at"] = gorm.Expr("now()")
+	return tx.Model(&models.Note{}).Where("id = ?", id).UpdateColumns(cols).Error
+}
+
+// visibilityColumns 计算公开/取消公开需要更新的列，与更新笔记时仅提供 public 字段的语义一致：
+// 公开即发布（清除定时、补齐首次发布时间、清除已过期的到期时间）；取消公开时已发布的变为下线，定时发布的变回草稿。
+// 笔记已处于目标状态时返回 nil。
+func visibilityColumns(note *models.Note, public bool, now time.Time) map[string]interface{} {
+	if public {
+		if note.Status == models.NoteStatusPublished && note.IsPubliclyVisible(now) {
+			return nil
+		}
+		cols := map[string]interface{}{
+			"status":     models.NoteStatusPublished,
+			"is_public":  true,
+			"publish_at": nil,
+		}
+		if note.PublishedAt == nil {
+			cols["published_at"] = now
+		}
+		if note.ExpiresAt != nil && !note.ExpiresAt.After(now) {
+			cols["expires_at"] = nil
+		}
+		return cols
+	}
+	switch note.Status {
+	case models.NoteStatusPublished:
+		return map[string]interface{}{"status": models.NoteStatusUnpublished, "is_public": false}
+	case models.NoteStatusScheduled:
+		return map[string]interface{}{"status": models.NoteStatusDraft, "is_public": false, "publish_at": nil}
+	}
+	return nil
+}
+
+// bulkUpdateTags 按批量操作计算笔记新的标签集合；集合有变化时替换标签、递增版本号并记录修订历史。
+// note 需预加载 Tags。
+func bulkUpdateTags(tx *gorm.DB, note *models.Note, op models.NoteBulkOp) (bool, error) {
+	current := tagNamesOf(note.Tags)
+	names := make([]string, 0, len(current)+len(op.Tags))
+	switch op.Action {
+	case models.NoteBulkAddTags:
+		names = append(append(names, current...), op.Tags...)
+	case models.NoteBulkRemoveTags:
+		drop := make(map[string]struct{}, len(op.Tags))
+		for _, t := range op.Tags {
+			drop[t] = struct{}{}
+		}
+		for _, n := range current {
+			if _, ok := drop[n]; !ok {
+				names = append(names, n)
+			}
+		}
+	case models.NoteBulkReplaceTags:
+		names = append(names, op.Tags...)
+	}
+	if sameTagSet(current, names) {
+		return false, nil
+	}
+
+	updated := *note
+	if err := replaceTags(tx, &updated, names); err != nil {
+		return false, err
+	}
+	if err := bumpNoteVersion(tx, note.ID, map[string]interface{}{}); err != nil {
+		return false, err
+	}
+	return true, writeNoteRevisions(tx, note, &updated)
+}
+
+// sameTagSet 判断两组标签名（忽略顺序与重复）是否相同。
+func sameTagSet(a, b []string) bool {
+	set := make(map[string]struct{}, len(a))
+	for _, n := range a {
+		set[n] = struct{}{}
+	}
+	other := make(map[string]struct{}, len(b))
+	for _, n := range b {
+		if _, ok := set[n]; !ok {
+			return false
+		}
+		other[n] = struct{}{}
+	}
+	return len(other) == len(set)
+}
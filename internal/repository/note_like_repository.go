@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 保证实现关系：若接口变更将在编译期报错
+var _ models.NoteLikeRepository = (*noteLikeRepository)(nil)
+
+// noteLikeRepository 提供 NoteLikeRepository 接口的 GORM 实现，依赖联合主键保证幂等。
+type noteLikeRepository struct{ db *gorm.DB }
+
+// NewNoteLikeRepository 构造基于 GORM 的点赞记录仓储实现。
+func NewNoteLikeRepository(db *gorm.DB) models.NoteLikeRepository {
+	return &noteLikeRepository{db: db}
+}
+
+// Like 插入点赞记录，冲突时忽略；通过影响行数判断是否为新增。
+func (r *noteLikeRepository) Like(noteID, userID uint) (bool, error) {
+	res := r.db.Omit("Note", "User").Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.NoteLike{NoteID: noteID, UserID: userID})
+	return res.RowsAffected == 1, res.Error
+}
+
+// Unlike 删除点赞记录；通过影响行数判断是否确有记录被删除。
+func (r *noteLikeRepository) Unlike(noteID, userID uint) (bool, error) {
+	res := r.db.Where("note_id = ? AND user_id = ?", noteID, userID).Delete(&models.NoteLike{})
+	return res.RowsAffected == 1, res.Error
+}
+
+// LikedNoteIDs 批量查询用户已点赞的笔记。
+func (r *noteLikeRepository) LikedNoteIDs(userID uint, noteIDs []uint) (map[uint]bool, error) {
+	liked := make(map[uint]bool, len(noteIDs))
+	if userID == 0 || len(noteIDs) == 0 {
+		return liked, nil
+	}
+	var ids []uint
+	if err := r.db.Model(&models.NoteLike{}).Where("user_id = ? AND note_id IN ?", userID, noteIDs).
+		Pluck("note_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		liked[id] = true
+	}
+	return liked, nil
+}
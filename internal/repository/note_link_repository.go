@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"strings"
+
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// 保证实现关系：若接口变更将在编译期报错
+var _ models.NoteLinkRepository = (*noteLinkRepository)(nil)
+
+// noteLinkRepository 提供 NoteLinkRepository 接口的 GORM 实现。
+type noteLinkRepository struct{ db *gorm.DB }
+
+// NewNoteLinkRepository 构造基于 GORM 的笔记链接仓储实现。
+func NewNoteLinkRepository(db *gorm.DB) models.NoteLinkRepository {
+	return &noteLinkRepository{db: db}
+}
+
+// linkTargetColumns 查询链接目标时需要的笔记字段
+var linkTargetColumns = []string{"notes.id AS note_id", "notes.title", "notes.slug", "notes.author_id", "notes.is_public", "notes.expires_at"}
+
+// Outgoing 列出出链：按 ID 的链接查询目标笔记是否仍存在，写入时未解析的 [[Title]] 链接在此按标题重新解析。
+func (r *noteLinkRepository) Outgoing(source *models.Note) ([]models.NoteLinkItem, error) {
+	var links []models.NoteLink
+	if err := r.db.Where("source_id = ?", source.ID).Order("id ASC").Find(&links).Error; err != nil {
+		return nil, err
+	}
+
+	var ids []uint
+	var titles []string
+	for _, l := range links {
+		if l.TargetID != nil {
+			ids = append(ids, *l.TargetID)
+		} else {
+			titles = append(titles, strings.ToLower(l.TargetTitle))
+		}
+	}
+
+	byID := make(map[uint]models.NoteLinkItem, len(ids))
+	if len(ids) > 0 {
+		var found []models.NoteLinkItem
+		if err := r.db.Model(&models.Note{}).Select(linkTargetColumns).
+			Where("notes.id IN ?", ids).Scan(&found).Error; err != nil {
+			return nil, err
+		}
+		for _, it := range found {
+			byID[*it.NoteID] = it
+		}
+	}
+	byTitle := make(map[string]models.NoteLinkItem, len(titles))
+	if len(titles) > 0 {
+		var found []models.NoteLinkItem
+		if err := r.db.Model(&models.Note{}).Select(linkTargetColumns).
+			Where("notes.author_id = ? AND lower(notes.title) IN ?", source.AuthorID, titles).
+			Order("notes.id ASC").Scan(&found).Error; err != nil {
+			return nil, err
+		}
+		for _, it := range found {
+			key := strings.ToLower(it.Title)
+			if _, ok := byTitle[key]; !ok {
+				byTitle[key] = it
+			}
+		}
+	}
+
+	items := make([]models.NoteLinkItem, 0, len(links))
+	for _, l := range links {
+		var it models.NoteLinkItem
+		var ok bool
+		if l.TargetID != nil {
+			it, ok = byID[*l.TargetID]
+		} else {
+			it, ok = byTitle[strings.ToLower(l.TargetTitle)]
+		}
+		if !ok {
+			// 目标不存在：保留链接中的 ID 或标题
+			it = models.NoteLinkItem{NoteID: l.TargetID, Title: l.TargetTitle}
+		}
+		it.Exists = ok
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+// Backlinks 列出引用 target 的笔记：按 ID 指向 target，或写入时未解析、标题与 target 相同的同作者 [[Title]] 链接。
+// 他人的 unlisted 笔记只能凭链接访问，不出现在反向链接中。
+func (r *noteLinkRepository) Backlinks(target *models.Note, viewerID uint) ([]models.NoteLinkItem, error) {
+	var items []models.NoteLinkItem
+	err := r.db.Model(&models.Note{}).Select(linkTargetColumns).
+		Where("notes.id <> ?", target.ID).
+		Where("EXISTS (SELECT 1 FROM note_links nl WHERE nl.source_id = notes.id AND (nl.target_id = ? OR (nl.target_id IS NULL AND notes.author_id = ? AND lower(nl.target_title) = lower(?))))",
+			target.ID, target.AuthorID, target.Title).
+		Where("(notes.author_id = ? OR (notes.is_public = ? AND NOT notes.unlisted AND (notes.expires_at IS NULL OR notes.expires_at > now())))", viewerID, true).
+		Order("notes.updated_at DESC, notes.id DESC").
+		Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Exists = true
+	}
+	return items, nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"strings"
+
+	"HYH-Blog-Gin/internal/markdown"
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// syncNoteLinks 在事务内根据正文重建笔记的出链：先删除旧的边，再写入本次解析出的引用。
+// [[Title]] 链接在作者自己的笔记中按标题（不区分大小写）解析，同名时取最早创建的笔记；指向自身的链接忽略。
+func syncNoteLinks(tx *gorm.DB, note *models.Note) error {
+	if err := tx.Where("source_id = ?", note.ID).Delete(&models.NoteLink{}).Error; err != nil {
+		return err
+	}
+	refs := markdown.ExtractLinks(note.Content)
+	if len(refs) == 0 {
+		return nil
+	}
+
+	var titles []string
+	for _, ref := range refs {
+		if ref.NoteID == 0 {
+			titles = append(titles, strings.ToLower(ref.Title))
+		}
+	}
+	resolved := make(map[string]uint, len(titles))
+	if len(titles) > 0 {
+		var rows []struct {
+			ID    uint
+			Title string
+		}
+		if err := tx.Model(&models.Note{}).Select("id", "title").
+			Where("author_id = ? AND lower(title) IN ?", note.AuthorID, titles).
+			Order("id ASC").Scan(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			key := strings.ToLower(row.Title)
+			if _, ok := resolved[key]; !ok {
+				resolved[key] = row.ID
+			}
+		}
+	}
+
+	links := make([]models.NoteLink, 0, len(refs))
+	for _, ref := range refs {
+		link := models.NoteLink{SourceID: note.ID}
+		if ref.NoteID != 0 {
+			id := ref.NoteID
+			link.TargetID = &id
+		} else {
+			link.TargetTitle = ref.Title
+			if id, ok := resolved[strings.ToLower(ref.Title)]; ok {
+				link.TargetID = &id
+			}
+		}
+		if link.TargetID != nil && *link.TargetID == note.ID {
+			continue
+		}
+		links = append(links, link)
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	return tx.Omit("Source").Create(&links).Error
+}
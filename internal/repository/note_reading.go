@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// BackfillReadingStats 按 ID 分批为已有笔记（含回收站）重新计算字数与阅读时间，供回填命令直接使用。
+// all 为 false 时只处理正文非空且 word_count 为 0 的笔记；只更新这两列，不修改 updated_at 与版本号。
+// 每批完成后调用 progress（可为 nil），返回实际发生变化的笔记数。
+func BackfillReadingStats(db *gorm.DB, all bool, batchSize int, progress func(scanned, updated int)) (int, error) {
+	var lastID uint
+	scanned, updated := 0, 0
+	for {
+		q := db.Unscoped().Model(&models.Note{}).Select("id", "content", "word_count", "reading_minutes").
+			Where("id > ?", lastID)
+		if !all {
+			q = q.Where("word_count = 0 AND content <> ''")
+		}
+		var batch []models.Note
+		if err := q.Order("id ASC").Limit(batchSize).Find(&batch).Error; err != nil {
+			return updated, err
+		}
+		if len(batch) == 0 {
+			return updated, nil
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for i := range batch {
+				n := &batch[i]
+				prevWords, prevMinutes := n.WordCount, n.ReadingMinutes
+				setReadingStats(n)
+				if n.WordCount == prevWords && n.ReadingMinutes == prevMinutes {
+					continue
+				}
+				err := tx.Unscoped().Model(&models.Note{}).Where("id = ?", n.ID).
+					UpdateColumns(map[string]interface{}{"word_count": n.WordCount, "reading_minutes": n.ReadingMinutes}).Error
+				if err != nil {
+					return err
+				}
+				updated++
+			}
+			return nil
+		})
+		if err != nil {
+			return updated, err
+		}
+		scanned += len(batch)
+		if progress != nil {
+			progress(scanned, updated)
+		}
+		if len(batch) < batchSize {
+			return updated, nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
@@ -0,0 +1,40 @@
+package repository
+
+import "HYH-Blog-Gin/internal/models"
+
+// relatedNotesSQL 以源笔记的标签集合 A 与候选笔记的标签集合 B 计算 |A∩B| / |A∪B|，
+// 只统计与源笔记至少共有一个标签的公开列出（不含 unlisted）、未过期、未删除的笔记。
+const relatedNotesSQL = `
+WITH src AS (
+	SELECT tag_id FROM note_tags WHERE note_id = @id
+), shared AS (
+	SELECT nt.note_id, COUNT(*) AS shared_tags
+	FROM note_tags nt JOIN src ON src.tag_id = nt.tag_id
+	WHERE nt.note_id <> @id
+	GROUP BY nt.note_id
+), sizes AS (
+	SELECT nt.note_id, COUNT(*) AS tag_count
+	FROM note_tags nt JOIN shared ON shared.note_id = nt.note_id
+	GROUP BY nt.note_id
+)
+SELECT n.id, n.title, n.slug, n.summary, n.cover_image, n.author_id, u.username,
+	shared.shared_tags,
+	shared.shared_tags::float8 / ((SELECT COUNT(*) FROM src) + sizes.tag_count - shared.shared_tags) AS jaccard,
+	n.views, n.likes, n.published_at, n.updated_at, n.expires_at
+FROM shared
+JOIN sizes ON sizes.note_id = shared.note_id
+JOIN notes n ON n.id = shared.note_id
+JOIN users u ON u.id = n.author_id AND u.deleted_at IS NULL
+WHERE n.deleted_at IS NULL AND n.is_public AND NOT n.unlisted AND (n.expires_at IS NULL OR n.expires_at > now())
+ORDER BY jaccard DESC, n.updated_at DESC, n.id DESC
+LIMIT @limit`
+
+// Related 按标签重合度列出候选的相关笔记；源笔记没有标签时返回空列表。
+func (r *noteRepository) Related(noteID uint, limit int) ([]models.RelatedNote, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	var notes []models.RelatedNote
+	err := r.db.Raw(relatedNotesSQL, map[string]interface{}{"id": noteID, "limit": limit}).Scan(&notes).Error
+	return notes, err
+}
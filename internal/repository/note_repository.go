@@ -1,7 +1,14 @@
 package repository
 
 import (
+	"fmt"
+	"html"
+	"strings"
+
+	"HYH-Blog-Gin/internal/config"
+	"HYH-Blog-Gin/internal/markdown"
 	"HYH-Blog-Gin/internal/models"
+	"HYH-Blog-Gin/internal/utils"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -16,20 +23,94 @@ var _ models.NoteRepository = (*noteRepository)(nil)
 // - 在标签关联增删时，使用仅含主键的 stub 实体避免额外 SELECT。
 // - 分页参数健壮性处理：当 limit<=0 时不应用分页，返回全部结果。
 // - 所有方法均是轻量无状态实现，可在多 goroutine 中共享。
-type noteRepository struct{ db *gorm.DB }
+// - 全文检索向量 search_vector 在每次写入笔记的同一事务内刷新，检索配置由 search 决定。
+type noteRepository struct {
+	db     *gorm.DB
+	search config.SearchConfig
+}
 
 // NewNoteRepository 构造一个基于 GORM 的笔记仓储实现。
-func NewNoteRepository(db *gorm.DB) models.NoteRepository { return &noteRepository{db: db} }
+func NewNoteRepository(db *gorm.DB, search config.SearchConfig) models.NoteRepository {
+	if search.TSConfig == "" {
+		search.TSConfig = "simple"
+	}
+	return &noteRepository{db: db, search: search}
+}
+
+// 高亮片段的临时标记，使用私有区字符以便在 HTML 转义后替换为 <mark>
+const (
+	highlightStart = "\uE000"
+	highlightStop  = "\uE001"
+)
 
-// Create 新建笔记记录。
-func (r *noteRepository) Create(note *models.Note) error { return r.db.Create(note).Error }
+// tsDocument 返回某一列参与全文检索的文档表达式及参数；开启 CJK 切分时在每个 CJK 字符两侧插入空格。
+func (r *noteRepository) tsDocument(column string) (string, []interface{}) {
+	if r.search.CJKSegment {
+		return fmt.Sprintf("regexp_replace(coalesce(%s, ''), ?, ?, 'g')", column), []interface{}{utils.CJKCharClassPG(), ` \1 `}
+	}
+	return fmt.Sprintf("coalesce(%s, '')", column), nil
+}
+
+// tsQuery 返回关键字对应的 tsquery 表达式及参数（websearch 语法，支持引号短语、OR 与 -排除）。
+func (r *noteRepository) tsQuery(query string) (string, []interface{}) {
+	if r.search.CJKSegment {
+		query = utils.SegmentCJKQuery(query)
+	}
+	return "websearch_to_tsquery(?::regconfig, ?)", []interface{}{r.search.TSConfig, query}
+}
+
+// refreshSearchVector 依据笔记当前的标题/摘要/正文重新计算 search_vector（标题 A、摘要 B、正文 C）。
+func (r *noteRepository) refreshSearchVector(tx *gorm.DB, id uint) error {
+	var parts []string
+	var args []interface{}
+	for _, c := range []struct{ column, weight string }{{"title", "A"}, {"summary", "B"}, {"content", "C"}} {
+		doc, docArgs := r.tsDocument(c.column)
+		parts = append(parts, fmt.Sprintf("setweight(to_tsvector(?::regconfig, %s), '%s')", doc, c.weight))
+		args = append(append(args, r.search.TSConfig), docArgs...)
+	}
+	args = append(args, id)
+	return tx.Exec("UPDATE notes SET search_vector = "+strings.Join(parts, " || ")+" WHERE id = ?", args...).Error
+}
+
+// setReadingStats 根据正文计算字数与阅读时间，在每次保存笔记前调用。
+func setReadingStats(note *models.Note) {
+	stats := markdown.Reading(note.Content)
+	note.WordCount, note.ReadingMinutes = stats.WordCount, stats.ReadingMinutes
+}
+
+// Create 新建笔记记录，并在同一事务内生成 slug、计算字数、写入全文检索向量并解析笔记链接。
+func (r *noteRepository) Create(note *models.Note) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := assignSlug(tx, note, nil); err != nil {
+			return err
+		}
+		setReadingStats(note)
+		if err := tx.Create(note).Error; err != nil {
+			return err
+		}
+		if err := r.refreshSearchVector(tx, note.ID); err != nil {
+			return err
+		}
+		return syncNoteLinks(tx, note)
+	})
+}
 
 // CreateWithTags 在单个事务中创建笔记并处理标签关联（保证原子性）。
 func (r *noteRepository) CreateWithTags(note *models.Note, tagNames []string) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := assignSlug(tx, note, nil); err != nil {
+			return err
+		}
+		setReadingStats(note)
 		if err := tx.Create(note).Error; err != nil {
 			return err
 		}
+		if err := r.refreshSearchVector(tx, note.ID); err != nil {
+			return err
+		}
+		if err := syncNoteLinks(tx, note); err != nil {
+			return err
+		}
 		if len(tagNames) == 0 {
 			return nil
 		}
@@ -97,82 +178,311 @@ func (r *noteRepository) FindByAuthor(authorID uint, page, limit int) ([]models.
 	return notes, total, err
 }
 
-// Search 在作者空间内按标题/内容与标签过滤，并按创建时间倒序返回。
-// - query 支持 ILIKE（PostgresSQL）模糊匹配。
-// - tags 非空时基于关联 Join 过滤并去重。
-func (r *noteRepository) Search(authorID uint, query string, tags []string) ([]models.Note, error) {
+// EachByAuthor 使用 FindInBatches 按主键分批读取作者的笔记，避免一次性加载全部笔记（用于导出）。
+func (r *noteRepository) EachByAuthor(authorID uint, batchSize int, fn func([]models.Note) error) error {
+	var batch []models.Note
+	return r.db.Preload("Tags").Where("author_id = ?", authorID).
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
+// publicAuthor 限制公开接口中预加载的作者字段，避免泄露邮箱等信息。
+func publicAuthor(db *gorm.DB) *gorm.DB {
+	return db.Select("id", "created_at", "updated_at", "username")
+}
+
+// FindPublic 分页查询公开列出（不含 unlisted）且未过期的笔记，按创建时间倒序返回列表与总数。
+// 可按作者与标签名过滤；当 limit<=0 时，不应用分页（返回全部）。
+func (r *noteRepository) FindPublic(filter models.PublicNoteFilter, page, limit int) ([]models.Note, int64, error) {
 	var notes []models.Note
-	// 基础条件 + 预加载
-	db := r.db.Model(&models.Note{}).Preload("Author").Preload("Tags").Where("author_id = ?", authorID)
-	if query != "" {
-		q := "%" + query + "%"
-		db = db.Where("title ILIKE ? OR content ILIKE ?", q, q)
+	var total int64
+
+	// 每次构造新的查询链，避免 Count 与 Find 共用语句状态
+	base := func() *gorm.DB {
+		q := r.db.Model(&models.Note{}).Where("notes.is_public = ? AND NOT notes.unlisted", true).
+			Where("(notes.expires_at IS NULL OR notes.expires_at > now())")
+		if filter.AuthorID != 0 {
+			q = q.Where("notes.author_id = ?", filter.AuthorID)
+		}
+		if filter.Tag != "" {
+			// 使用 EXISTS 子查询过滤标签，避免 Join 带来的重复行
+			q = q.Where("EXISTS (SELECT 1 FROM note_tags nt JOIN tags t ON t.id = nt.tag_id WHERE nt.note_id = notes.id AND t.name = ?)", filter.Tag)
+		}
+		return q
 	}
-	// 若根据标签过滤：使用关联名进行 Join，避免手写中间表名；并使用 Distinct 去重
-	if len(tags) > 0 {
-		db = db.Joins("Tags").Where("tags.name IN ?", tags).Distinct("notes.*")
+
+	if err := base().Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
-	err := db.Order("notes.created_at DESC").Find(&notes).Error
+
+	db := base().Preload("Author", publicAuthor).Preload("Tags").Order("notes.created_at DESC")
+	if limit > 0 {
+		if page <= 0 {
+			page = 1
+		}
+		offset := (page - 1) * limit
+		db = db.Offset(offset).Limit(limit)
+	}
+
+	err := db.Find(&notes).Error
+	return notes, total, err
+}
+
+// FindPublicByIDs 按主键批量查询公开且列出的笔记（不含 unlisted），预加载作者与标签。
+func (r *noteRepository) FindPublicByIDs(ids []uint) ([]models.Note, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var notes []models.Note
+	err := r.db.Preload("Author", publicAuthor).Preload("Tags").
+		Where("is_public = ? AND NOT unlisted", true).Where("(expires_at IS NULL OR expires_at > now())").
+		Where("id IN ?", ids).
+		Find(&notes).Error
 	return notes, err
 }
 
-// Update 根据主键保存全部字段。
-func (r *noteRepository) Update(note *models.Note) error { return r.db.Save(note).Error }
+// FindPublicByID 根据主键查询公开笔记；笔记不存在、非公开或已过期时返回 gorm.ErrRecordNotFound。
+func (r *noteRepository) FindPublicByID(id uint) (*models.Note, error) {
+	var note models.Note
+	err := r.db.Preload("Author", publicAuthor).Preload("Tags").
+		Where("is_public = ?", true).Where("(expires_at IS NULL OR expires_at > now())").
+		First(&note, "id = ?", id).Error
+	return &note, err
+}
+
+// noteSortOrders 排序方式到 ORDER BY 子句的白名单映射，防止 SQL 注入。
+// 相关度排序依赖关键字参数，单独在 Search 中处理。
+var noteSortOrders = map[string]string{
+	models.NoteSortNewest:  "notes.created_at DESC",
+	models.NoteSortOldest:  "notes.created_at ASC",
+	models.NoteSortUpdated: "notes.updated_at DESC",
+	models.NoteSortViews:   "notes.views DESC, notes.created_at DESC",
+	models.NoteSortLikes:   "notes.likes DESC, notes.created_at DESC",
+}
 
-// UpdateWithTags 在单个事务中更新笔记并替换标签集合（保证原子性）。
+// Search 在作者空间内按关键字、标签与创建时间范围过滤，分页返回列表与总数。
+// - query 基于 search_vector 全文检索（websearch 语法），结果填充 Highlight 高亮片段。
+// - tags 非空时通过 EXISTS 子查询过滤：MatchAll 要求包含全部标签，否则包含任一即可。
+// - 排序为空时，有关键字按相关度（ts_rank）倒序，否则按创建时间倒序；未知排序方式回退为创建时间倒序。
+// - 当 limit<=0 时，不应用分页（返回全部）。
+func (r *noteRepository) Search(authorID uint, opts models.NoteSearchOptions, page, limit int) ([]models.Note, int64, error) {
+	var notes []models.Note
+	var total int64
+
+	// 标签去重，MatchAll 需要按去重后的数量比较
+	tags := make([]string, 0, len(opts.Tags))
+	seen := make(map[string]struct{}, len(opts.Tags))
+	for _, t := range opts.Tags {
+		if _, ok := seen[t]; !ok && t != "" {
+			seen[t] = struct{}{}
+			tags = append(tags, t)
+		}
+	}
+
+	base := func() *gorm.DB {
+		q := r.db.Model(&models.Note{}).Where("notes.author_id = ?", authorID)
+		if opts.Query != "" {
+			tsq, tsqArgs := r.tsQuery(opts.Query)
+			q = q.Where("notes.search_vector @@ "+tsq, tsqArgs...)
+		}
+		if len(tags) > 0 {
+			if opts.MatchAll {
+				q = q.Where("(SELECT COUNT(DISTINCT t.id) FROM note_tags nt JOIN tags t ON t.id = nt.tag_id WHERE nt.note_id = notes.id AND t.name IN ?) = ?", tags, len(tags))
+			} else {
+				q = q.Where("EXISTS (SELECT 1 FROM note_tags nt JOIN tags t ON t.id = nt.tag_id WHERE nt.note_id = notes.id AND t.name IN ?)", tags)
+			}
+		}
+		if opts.Status != "" {
+			q = q.Where("notes.status = ?", opts.Status)
+		}
+		if opts.From != nil {
+			q = q.Where("notes.created_at >= ?", *opts.From)
+		}
+		if opts.To != nil {
+			q = q.Where("notes.created_at < ?", *opts.To)
+		}
+		return q
+	}
+
+	if err := base().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	sort := opts.Sort
+	if sort == "" && opts.Query != "" {
+		sort = models.NoteSortRelevance
+	}
+	db := base().Preload("Author").Preload("Tags")
+	if opts.Query != "" {
+		// 选取高亮片段：对正文计算 ts_headline
+		doc, docArgs := r.tsDocument("notes.content")
+		tsq, tsqArgs := r.tsQuery(opts.Query)
+		args := append(append([]interface{}{r.search.TSConfig}, docArgs...), tsqArgs...)
+		args = append(args, fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxFragments=2, MaxWords=35, MinWords=15`, highlightStart, highlightStop))
+		db = db.Select("notes.*, ts_headline(?::regconfig, "+doc+", "+tsq+", ?) AS highlight", args...)
+	}
+	if sort == models.NoteSortRelevance && opts.Query != "" {
+		tsq, tsqArgs := r.tsQuery(opts.Query)
+		db = db.Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(notes.search_vector, " + tsq + ") DESC, notes.created_at DESC",
+			Vars:               tsqArgs,
+			WithoutParentheses: true,
+		}})
+	} else {
+		order, ok := noteSortOrders[sort]
+		if !ok {
+			order = noteSortOrders[models.NoteSortNewest]
+		}
+		db = db.Order(order)
+	}
+	if limit > 0 {
+		if page <= 0 {
+			page = 1
+		}
+		offset := (page - 1) * limit
+		db = db.Offset(offset).Limit(limit)
+	}
+
+	if err := db.Find(&notes).Error; err != nil {
+		return nil, 0, err
+	}
+	for i := range notes {
+		notes[i].Highlight = r.formatHighlight(notes[i].Highlight)
+	}
+	return notes, total, nil
+}
+
+// formatHighlight 将 ts_headline 输出转为安全的 HTML 片段：还原 CJK 切分空格、转义 HTML，再把临时标记替换为 <mark>。
+func (r *noteRepository) formatHighlight(h string) string {
+	if h == "" {
+		return ""
+	}
+	if r.search.CJKSegment {
+		h = utils.JoinCJK(h, highlightStart, highlightStop)
+	}
+	h = html.EscapeString(strings.TrimSpace(h))
+	return strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>").Replace(h)
+}
+
+// Update 校验并递增乐观锁版本号后根据主键保存全部字段，不改变标签集合；与 UpdateWithTags 一样在同一事务内同步 slug、
+// 刷新全文检索向量与笔记链接并记录修订历史，任何更新都不会绕过修订记录。
+func (r *noteRepository) Update(note *models.Note) error {
+	return r.UpdateWithTags(note, nil)
+}
+
+// UpdateWithTags 在单个事务中校验乐观锁版本号、更新笔记、同步 slug 与笔记链接、替换标签集合并记录修订历史（保证原子性）。
 func (r *noteRepository) UpdateWithTags(note *models.Note, tagNames []string) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// 保存 note 本体
-		if err := tx.Save(note).Error; err != nil {
+		// 读取更新前的状态，用于在首次修订时写入基线版本
+		var prev models.Note
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Tags").First(&prev, "id = ?", note.ID).Error; err != nil {
 			return err
 		}
-		// 如果 tagNames 为 nil，表示不改变标签集合
-		if tagNames == nil {
-			return nil
+		if err := checkVersion(&prev, note); err != nil {
+			return err
 		}
-		// 如果为空数组则清空关联
-		if len(tagNames) == 0 {
-			if err := tx.Model(note).Association("Tags").Clear(); err != nil {
-				return err
-			}
-			return nil
+		// 保存 note 本体，同步 slug 并刷新全文检索向量
+		if err := assignSlug(tx, note, &prev); err != nil {
+			return err
 		}
-		// 去重并保持顺序
-		order := make([]string, 0, len(tagNames))
-		seen := make(map[string]struct{}, len(tagNames))
-		for _, n := range tagNames {
-			if _, ok := seen[n]; !ok {
-				seen[n] = struct{}{}
-				order = append(order, n)
-			}
+		setReadingStats(note)
+		if err := tx.Save(note).Error; err != nil {
+			return err
 		}
-		// 创建缺失的标签（并发安全）
-		var toCreate []models.Tag
-		for _, n := range order {
-			toCreate = append(toCreate, models.Tag{Name: n})
+		if err := recordSlugChange(tx, &prev, note); err != nil {
+			return err
 		}
-		if len(toCreate) > 0 {
-			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&toCreate).Error; err != nil {
-				return err
-			}
+		if err := r.refreshSearchVector(tx, note.ID); err != nil {
+			return err
 		}
-		// 取得最终标签集合
-		var final []models.Tag
-		if err := tx.Where("name IN ?", order).Find(&final).Error; err != nil {
+		if err := syncNoteLinks(tx, note); err != nil {
 			return err
 		}
-		// 使用 Replace 保证替换旧关联为新集合
-		if err := tx.Model(note).Association("Tags").Replace(&final); err != nil {
+		if err := replaceTags(tx, note, tagNames); err != nil {
 			return err
 		}
-		note.Tags = final
-		return nil
+		return writeNoteRevisions(tx, &prev, note)
 	})
 }
 
+// checkVersion 校验乐观锁版本号并为本次保存递增版本号；prev 需在事务内以 FOR UPDATE 读取，保证并发更新串行化。
+func checkVersion(prev, note *models.Note) error {
+	if note.Version != prev.Version {
+		return models.ErrNoteVersionConflict
+	}
+	note.Version = prev.Version + 1
+	return nil
+}
+
+// Version 只查询版本号列，供缓存层校验缓存是否过期。
+func (r *noteRepository) Version(id uint) (int64, error) {
+	var note models.Note
+	if err := r.db.Select("id", "version").First(&note, "id = ?", id).Error; err != nil {
+		return 0, err
+	}
+	return note.Version, nil
+}
+
+// replaceTags 在事务内替换笔记的标签集合：
+// tagNames 为 nil 表示不改变标签集合，为空数组表示清空关联。
+func replaceTags(tx *gorm.DB, note *models.Note, tagNames []string) error {
+	// 如果 tagNames 为 nil，表示不改变标签集合
+	if tagNames == nil {
+		return nil
+	}
+	// 如果为空数组则清空关联
+	if len(tagNames) == 0 {
+		if err := tx.Model(note).Association("Tags").Clear(); err != nil {
+			return err
+		}
+		note.Tags = []models.Tag{}
+		return nil
+	}
+	// 去重并保持顺序
+	order := make([]string, 0, len(tagNames))
+	seen := make(map[string]struct{}, len(tagNames))
+	for _, n := range tagNames {
+		if _, ok := seen[n]; !ok {
+			seen[n] = struct{}{}
+			order = append(order, n)
+		}
+	}
+	// 创建缺失的标签（并发安全）
+	var toCreate []models.Tag
+	for _, n := range order {
+		toCreate = append(toCreate, models.Tag{Name: n})
+	}
+	if len(toCreate) > 0 {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&toCreate).Error; err != nil {
+			return err
+		}
+	}
+	// 取得最终标签集合
+	var final []models.Tag
+	if err := tx.Where("name IN ?", order).Find(&final).Error; err != nil {
+		return err
+	}
+	// 使用 Replace 保证替换旧关联为新集合
+	if err := tx.Model(note).Association("Tags").Replace(&final); err != nil {
+		return err
+	}
+	note.Tags = final
+	return nil
+}
+
 // Delete 根据主键删除笔记（软删除）。
 func (r *noteRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Note{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// 软删除不会触发外键级联，收藏与系列成员关系需要在同一事务中显式清理
+		if err := tx.Where("note_id = ?", id).Delete(&models.Bookmark{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("note_id = ?", id).Delete(&models.SeriesNote{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Note{}, "id = ?", id).Error
+	})
 }
 
 // AddTags 为指定笔记追加标签集合。避免先 SELECT 笔记，直接使用仅含主键的 stub 实体。
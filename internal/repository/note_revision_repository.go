@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// 保证实现关系：若接口变更将在编译期报错
+var _ models.NoteRevisionRepository = (*noteRevisionRepository)(nil)
+
+// noteRevisionRepository 提供 NoteRevisionRepository 接口的 GORM 实现。
+type noteRevisionRepository struct{ db *gorm.DB }
+
+// NewNoteRevisionRepository 构造基于 GORM 的笔记修订记录仓储实现。
+func NewNoteRevisionRepository(db *gorm.DB) models.NoteRevisionRepository {
+	return &noteRevisionRepository{db: db}
+}
+
+// ListByNote 按版本号倒序分页列出某笔记的修订记录；当 limit<=0 时，不应用分页（返回全部）。
+func (r *noteRevisionRepository) ListByNote(noteID uint, page, limit int) ([]models.NoteRevision, int64, error) {
+	var revs []models.NoteRevision
+	var total int64
+	if err := r.db.Model(&models.NoteRevision{}).Where("note_id = ?", noteID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	db := r.db.Where("note_id = ?", noteID).Order("version DESC")
+	if limit > 0 {
+		if page <= 0 {
+			page = 1
+		}
+		db = db.Offset((page - 1) * limit).Limit(limit)
+	}
+	err := db.Find(&revs).Error
+	return revs, total, err
+}
+
+// FindByVersion 查询某笔记的指定版本。
+func (r *noteRevisionRepository) FindByVersion(noteID uint, version int) (*models.NoteRevision, error) {
+	var rev models.NoteRevision
+	err := r.db.First(&rev, "note_id = ? AND version = ?", noteID, version).Error
+	return &rev, err
+}
+
+// tagNamesOf 提取标签名列表。
+func tagNamesOf(tags []models.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// writeNoteRevisions 在更新事务内记录修订：
+// - 若该笔记尚无任何修订，先以更新前的状态 prev 写入基线版本，避免历史内容丢失；
+// - 再写入更新后的快照 note（标签以事务内的最终关联为准）。
+// 目前仅作者可以编辑笔记，因此编辑者记为 note.AuthorID。
+func writeNoteRevisions(tx *gorm.DB, prev, note *models.Note) error {
+	var latest int
+	if err := tx.Model(&models.NoteRevision{}).Where("note_id = ?", note.ID).
+		Select("COALESCE(MAX(version), 0)").Scan(&latest).Error; err != nil {
+		return err
+	}
+	if latest == 0 && prev != nil {
+		latest++
+		base := models.NoteRevision{
+			NoteID:    prev.ID,
+			Version:   latest,
+			Title:     prev.Title,
+			Summary:   prev.Summary,
+			Content:   prev.Content,
+			TagNames:  tagNamesOf(prev.Tags),
+			EditorID:  prev.AuthorID,
+			CreatedAt: prev.UpdatedAt,
+		}
+		if err := tx.Create(&base).Error; err != nil {
+			return err
+		}
+	}
+	var tags []models.Tag
+	if err := tx.Model(note).Association("Tags").Find(&tags); err != nil {
+		return err
+	}
+	rev := models.NoteRevision{
+		NoteID:   note.ID,
+		Version:  latest + 1,
+		Title:    note.Title,
+		Summary:  note.Summary,
+		Content:  note.Content,
+		TagNames: tagNamesOf(tags),
+		EditorID: note.AuthorID,
+	}
+	return tx.Create(&rev).Error
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"errors"
+
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// 保证实现关系：若接口变更将在编译期报错
+var _ models.NoteShareRepository = (*noteShareRepository)(nil)
+
+// noteShareRepository 提供 NoteShareRepository 接口的 GORM 实现。
+type noteShareRepository struct{ db *gorm.DB }
+
+// NewNoteShareRepository 构造基于 GORM 的分享链接仓储实现。
+func NewNoteShareRepository(db *gorm.DB) models.NoteShareRepository {
+	return &noteShareRepository{db: db}
+}
+
+// Create 新建分享链接。
+func (r *noteShareRepository) Create(share *models.NoteShare) error {
+	return r.db.Omit("Note").Create(share).Error
+}
+
+// FindByToken 按 Token 查询分享链接，不存在时返回 nil, nil。
+func (r *noteShareRepository) FindByToken(token string) (*models.NoteShare, error) {
+	var s models.NoteShare
+	err := r.db.Where("token = ?", token).First(&s).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// ListByNote 按创建时间倒序列出笔记的分享链接。
+func (r *noteShareRepository) ListByNote(noteID uint) ([]models.NoteShare, error) {
+	var shares []models.NoteShare
+	err := r.db.Where("note_id = ?", noteID).Order("created_at DESC, id DESC").Find(&shares).Error
+	return shares, err
+}
+
+// Delete 删除分享链接；同时按 note_id 限定，避免通过其他笔记的路径撤销。
+func (r *noteShareRepository) Delete(noteID, shareID uint) (bool, error) {
+	res := r.db.Where("id = ? AND note_id = ?", shareID, noteID).Delete(&models.NoteShare{})
+	return res.RowsAffected == 1, res.Error
+}
+
+// Consume 以单条带条件的 UPDATE 递增访问次数，并发访问也不会超过最大访问次数。
+func (r *noteShareRepository) Consume(id uint) (bool, error) {
+	res := r.db.Model(&models.NoteShare{}).
+		Where("id = ?", id).
+		Where("(expires_at IS NULL OR expires_at > now())").
+		Where("(max_views IS NULL OR views < max_views)").
+		UpdateColumns(map[string]interface{}{
+			"views":          gorm.Expr("views + 1"),
+			"last_viewed_at": gorm.Expr("now()"),
+		})
+	return res.RowsAffected == 1, res.Error
+}
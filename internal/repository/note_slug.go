@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"HYH-Blog-Gin/internal/models"
+	"HYH-Blog-Gin/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxSlugAttempts 为同一基础 slug 尝试的数字后缀上限，超出后改用笔记 ID 作为后缀。
+const maxSlugAttempts = 100
+
+// assignSlug 在写入前为笔记确定 slug：标题未变化且已有 slug 时保持不变，否则依据标题重新生成，
+// 与同一作者的其他笔记（含软删除）及其历史 slug 冲突时追加 -2、-3… 后缀。prev 为 nil 表示新建。
+func assignSlug(tx *gorm.DB, note *models.Note, prev *models.Note) error {
+	if prev != nil && prev.Slug != "" && prev.Title == note.Title {
+		note.Slug = prev.Slug
+		return nil
+	}
+	base := utils.Slugify(note.Title)
+	if prev != nil && prev.Slug != "" && utils.Slugify(prev.Title) == base {
+		// 标题变化但生成的基础 slug 相同（如仅大小写或标点不同），保持原 slug
+		note.Slug = prev.Slug
+		return nil
+	}
+
+	// 一次性取出所有可能冲突的 slug，再在内存中挑选第一个可用值
+	pattern := base + "-%"
+	var taken []string
+	if err := tx.Raw(`SELECT slug FROM notes WHERE author_id = ? AND id <> ? AND (slug = ? OR slug LIKE ?)
+		UNION SELECT slug FROM note_slug_history WHERE author_id = ? AND note_id <> ? AND (slug = ? OR slug LIKE ?)`,
+		note.AuthorID, note.ID, base, pattern, note.AuthorID, note.ID, base, pattern).Scan(&taken).Error; err != nil {
+		return err
+	}
+	used := make(map[string]struct{}, len(taken))
+	for _, s := range taken {
+		used[s] = struct{}{}
+	}
+	candidate := base
+	for i := 2; ; i++ {
+		if _, ok := used[candidate]; !ok {
+			break
+		}
+		if i > maxSlugAttempts {
+			// 极端情况下回退为时间戳后缀（新建时尚无 ID）
+			candidate = fmt.Sprintf("%s-%d", base, tx.NowFunc().UnixNano())
+			break
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+	note.Slug = candidate
+	return nil
+}
+
+// recordSlugChange 在 slug 变化后写入历史记录，使旧链接可以重定向；
+// 若新 slug 曾是本笔记的历史值，则删除该历史记录（slug 重新成为当前值）。
+func recordSlugChange(tx *gorm.DB, prev, note *models.Note) error {
+	if prev == nil || prev.Slug == "" || prev.Slug == note.Slug {
+		return nil
+	}
+	history := models.NoteSlugHistory{NoteID: note.ID, AuthorID: note.AuthorID, Slug: prev.Slug}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&history).Error; err != nil {
+		return err
+	}
+	return tx.Where("note_id = ? AND slug = ?", note.ID, note.Slug).Delete(&models.NoteSlugHistory{}).Error
+}
+
+// FindBySlug 按作者用户名与 slug 查询笔记，预加载作者（仅公开字段）与标签。
+// 当前 slug 未命中时查找历史 slug，返回其对应笔记（Slug 为当前值）；均未命中时返回 gorm.ErrRecordNotFound。
+func (r *noteRepository) FindBySlug(username, slug string) (*models.Note, error) {
+	var note models.Note
+	byAuthor := "notes.author_id = (SELECT id FROM users WHERE username = ? AND deleted_at IS NULL)"
+	err := r.db.Preload("Author", publicAuthor).Preload("Tags").
+		Where(byAuthor, username).Where("notes.slug = ?", slug).
+		First(&note).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return &note, err
+	}
+	err = r.db.Preload("Author", publicAuthor).Preload("Tags").
+		Where(byAuthor, username).
+		Where("notes.id = (SELECT h.note_id FROM note_slug_history h WHERE h.author_id = notes.author_id AND h.slug = ?)", slug).
+		First(&note).Error
+	return &note, err
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"time"
+
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// FindTrashed 分页列出作者回收站中的笔记（已软删除），按删除时间倒序；当 limit<=0 时，不应用分页（返回全部）。
+func (r *noteRepository) FindTrashed(authorID uint, page, limit int) ([]models.Note, int64, error) {
+	var notes []models.Note
+	var total int64
+
+	base := func() *gorm.DB {
+		return r.db.Unscoped().Model(&models.Note{}).
+			Where("notes.author_id = ? AND notes.deleted_at IS NOT NULL", authorID)
+	}
+	if err := base().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	db := base().Preload("Tags").Order("notes.deleted_at DESC, notes.id DESC")
+	if limit > 0 {
+		if page <= 0 {
+			page = 1
+		}
+		db = db.Offset((page - 1) * limit).Limit(limit)
+	}
+	err := db.Find(&notes).Error
+	return notes, total, err
+}
+
+// FindTrashedByID 根据主键查询回收站中的笔记，笔记不存在或未被删除时返回 gorm.ErrRecordNotFound。
+func (r *noteRepository) FindTrashedByID(id uint) (*models.Note, error) {
+	var note models.Note
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&note, "id = ?", id).Error
+	return &note, err
+}
+
+// Restore 把回收站中的笔记恢复为未删除状态，并递增乐观锁版本号；删除时已清理的收藏与系列关系不会恢复。
+func (r *noteRepository) Restore(id uint) error {
+	res := r.db.Unscoped().Model(&models.Note{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		UpdateColumns(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": gorm.Expr("now()"),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Purge 彻底删除回收站中的笔记，见 PurgeNotes。
+func (r *noteRepository) Purge(id uint) error {
+	var count int64
+	if err := r.db.Unscoped().Model(&models.Note{}).Where("id = ? AND deleted_at IS NOT NULL", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return PurgeNotes(r.db, []uint{id})
+}
+
+// PurgeNotes 在事务中彻底删除一组已软删除的笔记（未删除的笔记会被忽略）：
+// 显式删除 note_tags 关联，修订历史、历史 slug、评论、点赞、收藏、系列关系与出链由外键 ON DELETE CASCADE 一并删除。
+// 其他笔记指向这些笔记的链接保留，查询时标记为不存在。
+func PurgeNotes(db *gorm.DB, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		trashed := tx.Unscoped().Model(&models.Note{}).Select("id").Where("id IN ? AND deleted_at IS NOT NULL", ids)
+		if err := tx.Exec("DELETE FROM note_tags WHERE note_id IN (?)", trashed).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ? AND deleted_at IS NOT NULL", ids).Delete(&models.Note{}).Error
+	})
+}
+
+// TrashedBefore 返回删除时间早于 cutoff 的笔记 ID（最多 limit 条），供回收站清理任务分批处理。
+func TrashedBefore(db *gorm.DB, cutoff time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := db.Unscoped().Model(&models.Note{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Order("deleted_at ASC").Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"time"
+
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// PublicNoteCounters 重建热度排行所需的公开笔记计数，PublishedAt 为首次发布时间（缺失时为创建时间）。
+type PublicNoteCounters struct {
+	ID          uint
+	Views       int64
+	Likes       int64
+	PublishedAt time.Time
+}
+
+// EachPublicNoteCounters 按 ID 升序以键集分页遍历有阅读或点赞的公开列出（不含 unlisted）笔记，每批调用一次 fn，fn 返回错误时停止。
+// 供后台任务直接使用。
+func EachPublicNoteCounters(db *gorm.DB, batchSize int, fn func([]PublicNoteCounters) error) error {
+	var lastID uint
+	for {
+		var batch []PublicNoteCounters
+		err := db.Model(&models.Note{}).
+			Select("id, views, likes, COALESCE(published_at, created_at) AS published_at").
+			Where("is_public = ? AND NOT unlisted AND (expires_at IS NULL OR expires_at > now())", true).
+			Where("(views > 0 OR likes > 0) AND id > ?", lastID).
+			Order("id ASC").Limit(batchSize).
+			Scan(&batch).Error
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
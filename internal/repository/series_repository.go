@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"errors"
+
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// 保证实现关系：若接口变更将在编译期报错
+var _ models.SeriesRepository = (*seriesRepository)(nil)
+
+// seriesRepository 提供 SeriesRepository 接口的 GORM 实现。
+type seriesRepository struct{ db *gorm.DB }
+
+// NewSeriesRepository 构造基于 GORM 的系列仓储实现。
+func NewSeriesRepository(db *gorm.DB) models.SeriesRepository {
+	return &seriesRepository{db: db}
+}
+
+// seriesNoteCount 列表查询时附带的成员数量（不含已删除的笔记）
+const seriesNoteCount = "(SELECT COUNT(*) FROM series_notes sn JOIN notes n ON n.id = sn.note_id AND n.deleted_at IS NULL WHERE sn.series_id = series.id) AS note_count"
+
+// Create 新建系列。
+func (r *seriesRepository) Create(series *models.Series) error {
+	if err := r.db.Omit("Author").Create(series).Error; err != nil {
+		return err
+	}
+	return r.db.Preload("Author", publicAuthor).First(series, "id = ?", series.ID).Error
+}
+
+// FindByID 根据主键查询系列，预加载作者。
+func (r *seriesRepository) FindByID(id uint) (*models.Series, error) {
+	var s models.Series
+	err := r.db.Preload("Author", publicAuthor).First(&s, "id = ?", id).Error
+	return &s, err
+}
+
+// FindByNote 查询笔记所属的系列。
+func (r *seriesRepository) FindByNote(noteID uint) (*models.Series, error) {
+	var s models.Series
+	err := r.db.Joins("JOIN series_notes ON series_notes.series_id = series.id").
+		Where("series_notes.note_id = ?", noteID).First(&s).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// list 按更新时间倒序分页查询系列并附带成员数量；当 limit<=0 时，不应用分页（返回全部）。
+func (r *seriesRepository) list(base func() *gorm.DB, page, limit int) ([]models.Series, int64, error) {
+	var list []models.Series
+	var total int64
+	if err := base().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	db := base().Select("series.*, "+seriesNoteCount).Preload("Author", publicAuthor).
+		Order("series.updated_at DESC, series.id DESC")
+	if limit > 0 {
+		if page <= 0 {
+			page = 1
+		}
+		db = db.Offset((page - 1) * limit).Limit(limit)
+	}
+	err := db.Find(&list).Error
+	return list, total, err
+}
+
+// FindByAuthor 分页列出作者的全部系列。
+func (r *seriesRepository) FindByAuthor(authorID uint, page, limit int) ([]models.Series, int64, error) {
+	return r.list(func() *gorm.DB {
+		return r.db.Model(&models.Series{}).Where("series.author_id = ?", authorID)
+	}, page, limit)
+}
+
+// FindPublic 分页列出公开系列：至少有一篇未删除的成员笔记，且不存在未公开、不公开列出或已过期的成员笔记。
+func (r *seriesRepository) FindPublic(authorID uint, page, limit int) ([]models.Series, int64, error) {
+	return r.list(func() *gorm.DB {
+		q := r.db.Model(&models.Series{}).
+			Where("EXISTS (SELECT 1 FROM series_notes sn JOIN notes n ON n.id = sn.note_id AND n.deleted_at IS NULL WHERE sn.series_id = series.id)").
+			Where("NOT EXISTS (SELECT 1 FROM series_notes sn JOIN notes n ON n.id = sn.note_id AND n.deleted_at IS NULL WHERE sn.series_id = series.id AND NOT (n.is_public AND NOT n.unlisted AND (n.expires_at IS NULL OR n.expires_at > now())))")
+		if authorID != 0 {
+			q = q.Where("series.author_id = ?", authorID)
+		}
+		return q
+	}, page, limit)
+}
+
+// Items 按顺序列出系列的成员笔记。
+func (r *seriesRepository) Items(seriesID uint) ([]models.SeriesItem, error) {
+	var items []models.SeriesItem
+	err := r.db.Table("series_notes").
+		Select("notes.id AS note_id, notes.title, notes.slug, notes.status, notes.is_public, notes.unlisted, notes.expires_at").
+		Joins("JOIN notes ON notes.id = series_notes.note_id AND notes.deleted_at IS NULL").
+		Where("series_notes.series_id = ?", seriesID).
+		Order("series_notes.position ASC").
+		Scan(&items).Error
+	return items, err
+}
+
+// SetNotes 在事务中删除原有成员关系并按顺序重新写入（position 从 1 开始），同时刷新系列的更新时间。
+func (r *seriesRepository) SetNotes(seriesID uint, noteIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", seriesID).Delete(&models.SeriesNote{}).Error; err != nil {
+			return err
+		}
+		if len(noteIDs) > 0 {
+			rows := make([]models.SeriesNote, 0, len(noteIDs))
+			for i, id := range noteIDs {
+				rows = append(rows, models.SeriesNote{SeriesID: seriesID, NoteID: id, Position: i + 1})
+			}
+			if err := tx.Omit("Series", "Note").Create(&rows).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.Series{}).Where("id = ?", seriesID).Update("updated_at", gorm.Expr("now()")).Error
+	})
+}
+
+// Update 保存系列的标题与简介。
+func (r *seriesRepository) Update(series *models.Series) error {
+	return r.db.Model(series).Select("title", "description", "updated_at").Updates(series).Error
+}
+
+// Delete 删除系列及其成员关系。
+func (r *seriesRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("series_id = ?", id).Delete(&models.SeriesNote{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Series{}, "id = ?", id).Error
+	})
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"HYH-Blog-Gin/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// 保证实现关系：若接口变更将在编译期报错
+var _ models.SitemapRepository = (*sitemapRepository)(nil)
+
+// sitemapRepository 提供 SitemapRepository 接口的 GORM 实现，只查询生成地址所需的列。
+type sitemapRepository struct{ db *gorm.DB }
+
+// NewSitemapRepository 构造基于 GORM 的站点地图仓储实现。
+func NewSitemapRepository(db *gorm.DB) models.SitemapRepository {
+	return &sitemapRepository{db: db}
+}
+
+// publicNotes 公开列出（不含 unlisted）、未过期且未删除（含回收站）的笔记，条件与 FindPublic 一致
+func (r *sitemapRepository) publicNotes() *gorm.DB {
+	return r.db.Model(&models.Note{}).Where("notes.is_public = ? AND NOT notes.unlisted", true).
+		Where("(notes.expires_at IS NULL OR notes.expires_at > now())")
+}
+
+// EachPublicNote 使用 id > 上一批最大 ID 的键集分页，避免大偏移量 OFFSET 的全表扫描。
+func (r *sitemapRepository) EachPublicNote(batchSize int, fn func([]models.SitemapNote) error) error {
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	var lastID uint
+	for {
+		var batch []models.SitemapNote
+		err := r.publicNotes().
+			Select("notes.id, notes.slug, users.username, notes.updated_at").
+			Joins("JOIN users ON users.id = notes.author_id AND users.deleted_at IS NULL").
+			Where("notes.id > ?", lastID).
+			Order("notes.id ASC").Limit(batchSize).
+			Scan(&batch).Error
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+// PublicAuthors 按作者聚合公开笔记的最近更新时间。
+func (r *sitemapRepository) PublicAuthors() ([]models.SitemapGroup, error) {
+	var groups []models.SitemapGroup
+	err := r.publicNotes().
+		Select("users.id, users.username AS name, MAX(notes.updated_at) AS updated_at").
+		Joins("JOIN users ON users.id = notes.author_id AND users.deleted_at IS NULL").
+		Group("users.id, users.username").Order("users.id ASC").
+		Scan(&groups).Error
+	return groups, err
+}
+
+// PublicTags 按标签聚合公开笔记的最近更新时间。
+func (r *sitemapRepository) PublicTags() ([]models.SitemapGroup, error) {
+	var groups []models.SitemapGroup
+	err := r.publicNotes().
+		Select("tags.id, tags.name, MAX(notes.updated_at) AS updated_at").
+		Joins("JOIN note_tags ON note_tags.note_id = notes.id").
+		Joins("JOIN tags ON tags.id = note_tags.tag_id AND tags.deleted_at IS NULL").
+		Group("tags.id, tags.name").Order("tags.name ASC").
+		Scan(&groups).Error
+	return groups, err
+}
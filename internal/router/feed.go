@@ -0,0 +1,20 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"HYH-Blog-Gin/internal/handlers"
+)
+
+// registerFeedRoutes 注册 RSS/Atom 订阅源路由（无鉴权，位于站点根路径而不是 /api/v1 下，便于阅读器订阅）。
+func registerFeedRoutes(r *gin.Engine, feedHandler *handlers.FeedHandler) {
+	if feedHandler == nil {
+		return
+	}
+	r.GET("/feed.xml", feedHandler.SiteRSS)
+	r.GET("/atom.xml", feedHandler.SiteAtom)
+	r.GET("/users/:username/feed.xml", feedHandler.UserRSS)
+	r.GET("/users/:username/atom.xml", feedHandler.UserAtom)
+	r.GET("/tags/:name/feed.xml", feedHandler.TagRSS)
+	r.GET("/tags/:name/atom.xml", feedHandler.TagAtom)
+}
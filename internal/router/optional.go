@@ -0,0 +1,40 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"HYH-Blog-Gin/internal/auth"
+	"HYH-Blog-Gin/internal/handlers"
+	"HYH-Blog-Gin/internal/middleware"
+)
+
+// registerOptionalAuthRoutes 注册可选鉴权的路由：匿名可访问公开资源，登录用户额外可访问自己的私有资源。
+func registerOptionalAuthRoutes(r *gin.Engine, jwt *auth.JWTService, noteHandler *handlers.NoteHandler, commentHandler *handlers.CommentHandler, seriesHandler *handlers.SeriesHandler, linkHandler *handlers.NoteLinkHandler) {
+	if noteHandler == nil {
+		return
+	}
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.OptionalAuth(jwt))
+	{
+		v1.GET("/notes/:id", noteHandler.GetNote)
+		v1.GET("/users/:username/notes/:slug", noteHandler.GetNoteBySlug)
+		// 相关阅读：源笔记可见性与读取笔记一致，只推荐公开笔记
+		v1.GET("/notes/:id/related", noteHandler.GetRelatedNotes)
+
+		// 评论列表：匿名可读公开笔记的评论
+		if commentHandler != nil {
+			v1.GET("/notes/:id/comments", commentHandler.List)
+		}
+
+		// 系列详情：匿名可读公开系列，作者可读自己的全部系列
+		if seriesHandler != nil {
+			v1.GET("/series/:id", seriesHandler.Get)
+		}
+
+		// 笔记出链与反向链接：可见性与读取笔记一致
+		if linkHandler != nil {
+			v1.GET("/notes/:id/links", linkHandler.Links)
+			v1.GET("/notes/:id/backlinks", linkHandler.Backlinks)
+		}
+	}
+}
@@ -13,7 +13,7 @@ import (
 )
 
 // registerProtectedRoutes 注册需要鉴权的路由，统一在 /api/v1 前缀下。
-func registerProtectedRoutes(r *gin.Engine, cfg *config.Config, jwt *auth.JWTService, userHandler *handlers.UserHandler, noteHandler *handlers.NoteHandler, imageHandler *handlers.ImageHandler, tagHandler *handlers.TagHandler, rdb *redis.Client) {
+func registerProtectedRoutes(r *gin.Engine, cfg *config.Config, jwt *auth.JWTService, userHandler *handlers.UserHandler, noteHandler *handlers.NoteHandler, imageHandler *handlers.ImageHandler, tagHandler *handlers.TagHandler, revisionHandler *handlers.NoteRevisionHandler, commentHandler *handlers.CommentHandler, bookmarkHandler *handlers.BookmarkHandler, seriesHandler *handlers.SeriesHandler, importHandler *handlers.NoteImportHandler, exportHandler *handlers.NoteExportHandler, rdb *redis.Client) {
 	v1 := r.Group("/api/v1")
 	// 使用鉴权中间件
 	v1.Use(middleware.AuthMiddleware(jwt))
@@ -23,15 +23,29 @@ func registerProtectedRoutes(r *gin.Engine, cfg *config.Config, jwt *auth.JWTSer
 
 		// 笔记相关
 		v1.GET("/notes", noteHandler.GetNotes)
+		v1.GET("/notes/search", noteHandler.SearchNotes)
 		v1.POST("/notes", noteHandler.CreateNote)
-		v1.GET("/notes/:id", noteHandler.GetNote)
+		v1.POST("/notes/bulk", noteHandler.BulkNotes)
+		if importHandler != nil {
+			v1.POST("/notes/import", importHandler.Import)
+		}
+		if exportHandler != nil {
+			v1.GET("/notes/export", exportHandler.Export)
+		}
 		v1.PUT("/notes/:id", noteHandler.UpdateNote)
 		v1.DELETE("/notes/:id", noteHandler.DeleteNote)
-		// 点赞接口 - 使用配置限流
+		// 回收站：列表、恢复、彻底删除
+		v1.GET("/notes/trash", noteHandler.ListTrash)
+		v1.POST("/notes/:id/restore", noteHandler.RestoreNote)
+		v1.DELETE("/notes/:id/purge", noteHandler.PurgeNote)
+		// GET /notes/:id 使用可选鉴权，见 registerOptionalAuthRoutes
+
+		// 点赞/取消点赞 - 每个用户对同一笔记只计一次，使用配置限流
 		likeRule := cfg.RateLimit.Like
 		likeWindow := time.Duration(likeRule.WindowSeconds) * time.Second
 		likeLimiter := middleware.RateLimitUser(rdb, "like", likeRule.Limit, likeWindow)
 		v1.POST("/notes/:id/like", likeLimiter, noteHandler.LikeNote)
+		v1.DELETE("/notes/:id/like", likeLimiter, noteHandler.UnlikeNote)
 
 		// 图片管理：上传、列表、info、删除（统一为 /images）
 		if imageHandler != nil {
@@ -45,6 +59,43 @@ func registerProtectedRoutes(r *gin.Engine, cfg *config.Config, jwt *auth.JWTSer
 			v1.DELETE("/images", imageHandler.Delete) // keep query param ?url=...
 		}
 
+		// 笔记修订历史：列表、单个版本、对比、恢复（仅作者）
+		if revisionHandler != nil {
+			v1.GET("/notes/:id/revisions", revisionHandler.List)
+			v1.GET("/notes/:id/revisions/diff", revisionHandler.Diff)
+			v1.GET("/notes/:id/revisions/:version", revisionHandler.Get)
+			v1.POST("/notes/:id/revisions/:version/restore", revisionHandler.Restore)
+		}
+
+		// 评论：发表（按用户限流）、编辑、删除；列表使用可选鉴权，见 registerOptionalAuthRoutes
+		if commentHandler != nil {
+			commentRule := cfg.RateLimit.Comment
+			commentWindow := time.Duration(commentRule.WindowSeconds) * time.Second
+			commentLimiter := middleware.RateLimitUser(rdb, "comment", commentRule.Limit, commentWindow)
+			v1.POST("/notes/:id/comments", commentLimiter, commentHandler.Create)
+			v1.PUT("/comments/:id", commentHandler.Update)
+			v1.DELETE("/comments/:id", commentHandler.Delete)
+		}
+
+		// 收藏（阅读列表）：收藏/取消收藏、列表与收藏夹
+		if bookmarkHandler != nil {
+			v1.POST("/notes/:id/bookmark", bookmarkHandler.Add)
+			v1.DELETE("/notes/:id/bookmark", bookmarkHandler.Remove)
+			v1.GET("/bookmarks", bookmarkHandler.List)
+			v1.GET("/bookmarks/folders", bookmarkHandler.Folders)
+		}
+
+		// 系列：增删改、成员插入/移除/重排（仅作者）；详情使用可选鉴权，见 registerOptionalAuthRoutes
+		if seriesHandler != nil {
+			v1.GET("/series", seriesHandler.ListMine)
+			v1.POST("/series", seriesHandler.Create)
+			v1.PUT("/series/:id", seriesHandler.Update)
+			v1.DELETE("/series/:id", seriesHandler.Delete)
+			v1.POST("/series/:id/notes", seriesHandler.InsertNote)
+			v1.PUT("/series/:id/notes", seriesHandler.Reorder)
+			v1.DELETE("/series/:id/notes/:note_id", seriesHandler.RemoveNote)
+		}
+
 		// 标签管理：CRUD
 		if tagHandler != nil {
 			v1.GET("/tags", tagHandler.List)
@@ -12,7 +12,7 @@ import (
 )
 
 // registerPublicRoutes 注册公开可访问的 API 路由（无鉴权），所有公开路由统一在 /api/v1 前缀下。
-func registerPublicRoutes(r *gin.Engine, cfg *config.Config, userHandler *handlers.UserHandler, rdb *redis.Client) {
+func registerPublicRoutes(r *gin.Engine, cfg *config.Config, userHandler *handlers.UserHandler, noteHandler *handlers.NoteHandler, seriesHandler *handlers.SeriesHandler, rdb *redis.Client) {
 	v1 := r.Group("/api/v1")
 	{
 		// 登录限流（按 IP）
@@ -22,5 +22,17 @@ func registerPublicRoutes(r *gin.Engine, cfg *config.Config, userHandler *handle
 
 		v1.POST("/register", userHandler.Register)
 		v1.POST("/login", loginLimiter, userHandler.Login)
+
+		// 公开笔记（仅返回 is_public = true 的笔记）
+		if noteHandler != nil {
+			v1.GET("/public/notes", noteHandler.GetPublicNotes)
+			v1.GET("/public/notes/trending", noteHandler.GetTrendingNotes)
+			v1.GET("/public/notes/:id", noteHandler.GetPublicNote)
+		}
+
+		// 公开系列（全部成员笔记公开）
+		if seriesHandler != nil {
+			v1.GET("/public/series", seriesHandler.ListPublic)
+		}
 	}
 }
@@ -24,7 +24,7 @@ import (
 
 // SetupRouter 构建并返回 Gin 引擎，集中注册中间件与路由。
 // 统一 API 前缀为 /api/v1；静态资源与 CORS/日志中间件按配置注入。
-func SetupRouter(cfg *config.Config, jwt *auth.JWTService, userHandler *handlers.UserHandler, noteHandler *handlers.NoteHandler, imageHandler *handlers.ImageHandler, tagHandler *handlers.TagHandler, db *database.DB, rdb *redis.Client) *gin.Engine {
+func SetupRouter(cfg *config.Config, jwt *auth.JWTService, userHandler *handlers.UserHandler, noteHandler *handlers.NoteHandler, imageHandler *handlers.ImageHandler, tagHandler *handlers.TagHandler, revisionHandler *handlers.NoteRevisionHandler, commentHandler *handlers.CommentHandler, bookmarkHandler *handlers.BookmarkHandler, seriesHandler *handlers.SeriesHandler, linkHandler *handlers.NoteLinkHandler, importHandler *handlers.NoteImportHandler, exportHandler *handlers.NoteExportHandler, feedHandler *handlers.FeedHandler, sitemapHandler *handlers.SitemapHandler, shareHandler *handlers.NoteShareHandler, db *database.DB, rdb *redis.Client) *gin.Engine {
 	r := gin.New()
 
 	// 中间件：请求ID、日志、恢复、CORS
@@ -170,8 +170,12 @@ func SetupRouter(cfg *config.Config, jwt *auth.JWTService, userHandler *handlers
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/api/v1/swagger.json")))
 
 	// register routes
-	registerPublicRoutes(r, cfg, userHandler, rdb)
-	registerProtectedRoutes(r, cfg, jwt, userHandler, noteHandler, imageHandler, tagHandler, rdb)
+	registerPublicRoutes(r, cfg, userHandler, noteHandler, seriesHandler, rdb)
+	registerOptionalAuthRoutes(r, jwt, noteHandler, commentHandler, seriesHandler, linkHandler)
+	registerProtectedRoutes(r, cfg, jwt, userHandler, noteHandler, imageHandler, tagHandler, revisionHandler, commentHandler, bookmarkHandler, seriesHandler, importHandler, exportHandler, rdb)
+	registerFeedRoutes(r, feedHandler)
+	registerSitemapRoutes(r, sitemapHandler)
+	registerShareRoutes(r, cfg, jwt, shareHandler, rdb)
 
 	return r
 }
@@ -0,0 +1,32 @@
+package router
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+
+	"HYH-Blog-Gin/internal/auth"
+	"HYH-Blog-Gin/internal/config"
+	"HYH-Blog-Gin/internal/handlers"
+	"HYH-Blog-Gin/internal/middleware"
+)
+
+// registerShareRoutes 注册分享链接路由：管理接口在 /api/v1 下且需要鉴权（仅作者）；
+// 打开分享链接的 /s/:token 位于站点根路径、无需鉴权，按 IP 限流以限制对 Token 与密码的猜测。
+func registerShareRoutes(r *gin.Engine, cfg *config.Config, jwt *auth.JWTService, shareHandler *handlers.NoteShareHandler, rdb *redis.Client) {
+	if shareHandler == nil {
+		return
+	}
+	v1 := r.Group("/api/v1")
+	v1.Use(middleware.AuthMiddleware(jwt))
+	{
+		v1.POST("/notes/:id/shares", shareHandler.Create)
+		v1.GET("/notes/:id/shares", shareHandler.List)
+		v1.DELETE("/notes/:id/shares/:share_id", shareHandler.Revoke)
+	}
+
+	rule := cfg.RateLimit.Share
+	limiter := middleware.RateLimitIP(rdb, "share", rule.Limit, time.Duration(rule.WindowSeconds)*time.Second)
+	r.GET("/s/:token", limiter, shareHandler.Open)
+}
@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"HYH-Blog-Gin/internal/handlers"
+)
+
+// registerSitemapRoutes 注册站点地图与 robots.txt 路由（无鉴权，位于站点根路径）。
+func registerSitemapRoutes(r *gin.Engine, sitemapHandler *handlers.SitemapHandler) {
+	if sitemapHandler == nil {
+		return
+	}
+	r.GET("/sitemap.xml", sitemapHandler.Sitemap)
+	r.GET("/sitemap/:file", sitemapHandler.SitemapPage)
+	r.GET("/robots.txt", sitemapHandler.Robots)
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"HYH-Blog-Gin/internal/models"
+)
+
+var (
+	ErrInvalidFolder     = errors.New("folder must be at most 64 characters")
+	ErrInvalidAnnotation = errors.New("annotation must be at most 2000 characters")
+)
+
+// 收藏字段长度限制（字符数）
+const (
+	MaxBookmarkFolderLength     = 64
+	MaxBookmarkAnnotationLength = 2000
+)
+
+// BookmarkInput 收藏的可选字段，nil 表示未提供（更新已有收藏时保持不变）。
+type BookmarkInput struct {
+	Folder     *string
+	Annotation *string
+}
+
+// BookmarkService 提供收藏（阅读列表）相关业务逻辑：
+// - 只能收藏请求者可见的笔记（公开未过期，或自己的笔记）；
+// - 重复收藏同一笔记时更新收藏夹与批注，不会产生重复记录；
+// - 批注仅收藏者本人可见。
+type BookmarkService interface {
+	// Add 收藏笔记或更新已有收藏，返回收藏与是否为新建
+	Add(userID, noteID uint, in BookmarkInput) (*models.Bookmark, bool, error)
+	// Remove 取消收藏，幂等：未收藏时返回 false
+	Remove(userID, noteID uint) (bool, error)
+	List(userID uint, folder *string, page, limit int) ([]models.Bookmark, int64, error)
+	Folders(userID uint) ([]models.BookmarkFolder, error)
+}
+
+type bookmarkService struct {
+	notes     models.NoteRepository
+	bookmarks models.BookmarkRepository
+}
+
+// NewBookmarkService 创建 BookmarkService 实例。
+func NewBookmarkService(notes models.NoteRepository, bookmarks models.BookmarkRepository) BookmarkService {
+	return &bookmarkService{notes: notes, bookmarks: bookmarks}
+}
+
+// applyBookmarkInput 规范化并校验输入，写入收藏。
+func applyBookmarkInput(b *models.Bookmark, in BookmarkInput) error {
+	if in.Folder != nil {
+		folder := strings.TrimSpace(*in.Folder)
+		if utf8.RuneCountInString(folder) > MaxBookmarkFolderLength {
+			return ErrInvalidFolder
+		}
+		b.Folder = folder
+	}
+	if in.Annotation != nil {
+		annotation := strings.TrimSpace(*in.Annotation)
+		if utf8.RuneCountInString(annotation) > MaxBookmarkAnnotationLength {
+			return ErrInvalidAnnotation
+		}
+		b.Annotation = annotation
+	}
+	return nil
+}
+
+// Add 收藏笔记；已收藏时按提供的字段更新收藏夹与批注。
+func (s *bookmarkService) Add(userID, noteID uint, in BookmarkInput) (*models.Bookmark, bool, error) {
+	note, err := s.notes.FindByID(noteID)
+	if err != nil || note == nil || note.ID == 0 {
+		return nil, false, ErrNotFound
+	}
+	if !note.IsPubliclyVisible(time.Now()) && note.AuthorID != userID {
+		return nil, false, ErrForbidden
+	}
+
+	existing, err := s.bookmarks.Find(userID, noteID)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		if err := applyBookmarkInput(existing, in); err != nil {
+			return nil, false, err
+		}
+		if err := s.bookmarks.Update(existing); err != nil {
+			return nil, false, err
+		}
+		return existing, false, nil
+	}
+
+	b := &models.Bookmark{UserID: userID, NoteID: noteID}
+	if err := applyBookmarkInput(b, in); err != nil {
+		return nil, false, err
+	}
+	if err := s.bookmarks.Create(b); err != nil {
+		return nil, false, err
+	}
+	return b, true, nil
+}
+
+// Remove 取消收藏。笔记已删除时收藏已被清理，同样返回 false。
+func (s *bookmarkService) Remove(userID, noteID uint) (bool, error) {
+	return s.bookmarks.Delete(userID, noteID)
+}
+
+// List 分页列出用户的收藏，folder 为 nil 表示全部收藏夹。
+func (s *bookmarkService) List(userID uint, folder *string, page, limit int) ([]models.Bookmark, int64, error) {
+	if folder != nil {
+		f := strings.TrimSpace(*folder)
+		folder = &f
+	}
+	return s.bookmarks.List(userID, folder, page, limit)
+}
+
+// Folders 列出用户的收藏夹及其收藏数量。
+func (s *bookmarkService) Folders(userID uint) ([]models.BookmarkFolder, error) {
+	return s.bookmarks.Folders(userID)
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"HYH-Blog-Gin/internal/models"
+)
+
+var (
+	ErrInvalidComment = errors.New("comment content must be between 1 and 5000 characters")
+	ErrInvalidParent  = errors.New("parent comment not found on this note")
+	ErrCommentsClosed = errors.New("comments are only allowed on public notes")
+)
+
+// MaxCommentLength 单条评论的最大字符数
+const MaxCommentLength = 5000
+
+// CommentService 提供评论相关业务逻辑：
+// - 只能在公开且未过期的笔记下发表评论或回复；
+// - 评论作者可以编辑、删除自己的评论，笔记作者可以删除其笔记下的任意评论；
+// - 删除为软删除，保留节点以维持回复结构。
+type CommentService interface {
+	List(userID, noteID uint, page, limit int) ([]*models.Comment, int64, error)
+	Create(userID, noteID uint, parentID *uint, content string) (*models.Comment, error)
+	Update(userID, id uint, content string) (*models.Comment, error)
+	Delete(userID, id uint) error
+}
+
+type commentService struct {
+	notes    models.NoteRepository
+	comments models.CommentRepository
+}
+
+// NewCommentService 创建 CommentService 实例。
+func NewCommentService(notes models.NoteRepository, comments models.CommentRepository) CommentService {
+	return &commentService{notes: notes, comments: comments}
+}
+
+// normalizeComment 去除首尾空白并校验长度。
+func normalizeComment(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" || utf8.RuneCountInString(content) > MaxCommentLength {
+		return "", ErrInvalidComment
+	}
+	return content, nil
+}
+
+// List 分页返回笔记下的讨论串（顶层评论及其嵌套回复）。userID 为 0 表示匿名访问。
+// 笔记对请求者不可见时与读取笔记一致地返回 forbidden；已删除的评论清空内容与作者，没有未删除回复的已删除评论不返回。
+func (s *commentService) List(userID, noteID uint, page, limit int) ([]*models.Comment, int64, error) {
+	note, err := s.notes.FindByID(noteID)
+	if err != nil || note == nil || note.ID == 0 {
+		return nil, 0, ErrNotFound
+	}
+	if !note.IsPubliclyVisible(time.Now()) && note.AuthorID != userID {
+		return nil, 0, ErrForbidden
+	}
+
+	roots, total, err := s.comments.ListRoots(noteID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	rootIDs := make([]uint, 0, len(roots))
+	for _, c := range roots {
+		rootIDs = append(rootIDs, c.ID)
+	}
+	replies, err := s.comments.ListByRoots(rootIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 按 ParentID 组装树，回复已按创建时间排序
+	nodes := make(map[uint]*models.Comment, len(roots)+len(replies))
+	threads := make([]*models.Comment, 0, len(roots))
+	for i := range roots {
+		nodes[roots[i].ID] = &roots[i]
+		threads = append(threads, &roots[i])
+	}
+	for i := range replies {
+		nodes[replies[i].ID] = &replies[i]
+	}
+	for i := range replies {
+		if parent, ok := nodes[*replies[i].ParentID]; ok {
+			parent.Replies = append(parent.Replies, &replies[i])
+		}
+	}
+	for _, c := range threads {
+		pruneDeleted(c)
+	}
+	return threads, total, nil
+}
+
+// pruneDeleted 递归移除没有存活回复的已删除评论，并隐藏已删除评论的作者信息；返回该节点是否仍需保留。
+func pruneDeleted(c *models.Comment) bool {
+	kept := c.Replies[:0]
+	for _, r := range c.Replies {
+		if pruneDeleted(r) {
+			kept = append(kept, r)
+		}
+	}
+	c.Replies = kept
+	if c.IsDeleted() {
+		c.AuthorID = 0
+		c.Author = models.User{}
+		return len(c.Replies) > 0
+	}
+	return true
+}
+
+// Create 在笔记下发表评论；parentID 非空时作为对该评论的回复（父评论须属于同一笔记且未删除）。
+func (s *commentService) Create(userID, noteID uint, parentID *uint, content string) (*models.Comment, error) {
+	content, err := normalizeComment(content)
+	if err != nil {
+		return nil, err
+	}
+	note, err := s.notes.FindByID(noteID)
+	if err != nil || note == nil || note.ID == 0 {
+		return nil, ErrNotFound
+	}
+	if !note.IsPubliclyVisible(time.Now()) {
+		return nil, ErrCommentsClosed
+	}
+
+	comment := &models.Comment{NoteID: noteID, AuthorID: userID, Content: content}
+	if parentID != nil {
+		parent, err := s.comments.FindByID(*parentID)
+		if err != nil || parent == nil || parent.ID == 0 || parent.NoteID != noteID || parent.IsDeleted() {
+			return nil, ErrInvalidParent
+		}
+		comment.ParentID = &parent.ID
+		comment.RootID = parent.RootID
+		if comment.RootID == nil {
+			comment.RootID = &parent.ID
+		}
+	}
+	if err := s.comments.Create(comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// Update 编辑评论内容，仅评论作者可编辑，已删除的评论不可编辑。
+func (s *commentService) Update(userID, id uint, content string) (*models.Comment, error) {
+	content, err := normalizeComment(content)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.comments.FindByID(id)
+	if err != nil || comment == nil || comment.ID == 0 || comment.IsDeleted() {
+		return nil, ErrNotFound
+	}
+	if comment.AuthorID != userID {
+		return nil, ErrForbidden
+	}
+	if comment.Content == content {
+		return comment, nil
+	}
+	now := time.Now()
+	comment.Content = content
+	comment.EditedAt = &now
+	if err := s.comments.Update(comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// Delete 软删除评论并清空内容；评论作者或所属笔记的作者可删除。
+func (s *commentService) Delete(userID, id uint) error {
+	comment, err := s.comments.FindByID(id)
+	if err != nil || comment == nil || comment.ID == 0 || comment.IsDeleted() {
+		return ErrNotFound
+	}
+	if comment.AuthorID != userID {
+		note, err := s.notes.FindByID(comment.NoteID)
+		if err != nil || note == nil || note.AuthorID != userID {
+			return ErrForbidden
+		}
+	}
+	now := time.Now()
+	comment.Content = ""
+	comment.DeletedAt = &now
+	return s.comments.Update(comment)
+}
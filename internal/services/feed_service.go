@@ -0,0 +1,126 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"html"
+	"time"
+
+	"HYH-Blog-Gin/internal/feed"
+	"HYH-Blog-Gin/internal/markdown"
+	"HYH-Blog-Gin/internal/models"
+)
+
+// 订阅源范围
+const (
+	FeedScopeSite = "site" // 全站
+	FeedScopeUser = "user" // 指定作者，value 为用户名
+	FeedScopeTag  = "tag"  // 指定标签，value 为标签名
+)
+
+// FeedDocument 生成好的订阅源：ETag 为内容摘要（带引号），LastModified 为条目中最近的更新时间（没有条目时为零值）。
+type FeedDocument struct {
+	XML          string    `json:"xml"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// FeedService 由公开且未过期的笔记生成 RSS/Atom 订阅源。
+type FeedService interface {
+	// Feed 生成订阅源，format 为 feed.FormatRSS 或 feed.FormatAtom，scope 为 FeedScope* 常量；作者不存在时返回 ErrNotFound
+	Feed(format, scope, value string) (*FeedDocument, error)
+}
+
+type feedService struct {
+	notes       models.NoteRepository
+	users       models.UserRepository
+	site        SiteSettings
+	fullContent bool
+	limit       int
+}
+
+// NewFeedService 创建 FeedService 实例；fullContent 为 true 时条目包含渲染后的全文，否则只包含摘要；limit 为每个订阅源的条目数。
+func NewFeedService(notes models.NoteRepository, users models.UserRepository, site SiteSettings, fullContent bool, limit int) FeedService {
+	return &feedService{notes: notes, users: users, site: site, fullContent: fullContent, limit: limit}
+}
+
+// Feed 读取最新的公开笔记并生成 XML。
+func (s *feedService) Feed(format, scope, value string) (*FeedDocument, error) {
+	file := "feed.xml"
+	if format == feed.FormatAtom {
+		file = "atom.xml"
+	}
+	ch := feed.Channel{Title: s.site.Title, Description: s.site.Description, Link: s.site.URL}
+	var filter models.PublicNoteFilter
+	switch scope {
+	case FeedScopeUser:
+		user, err := s.users.FindByUsername(value)
+		if err != nil || user == nil || user.ID == 0 {
+			return nil, ErrNotFound
+		}
+		filter.AuthorID = user.ID
+		ch.Title = user.Username + " - " + s.site.Title
+		ch.Description = user.Username + " 的公开笔记"
+		ch.Self = s.site.URL + "/users/" + escapeURLValue(user.Username) + "/" + file
+	case FeedScopeTag:
+		filter.Tag = value
+		ch.Title = "#" + value + " - " + s.site.Title
+		ch.Description = "标签 " + value + " 下的公开笔记"
+		ch.Self = s.site.URL + "/tags/" + escapeURLValue(value) + "/" + file
+	default:
+		ch.Self = s.site.URL + "/" + file
+	}
+
+	notes, _, err := s.notes.FindPublic(filter, 1, s.limit)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]feed.Item, 0, len(notes))
+	for i := range notes {
+		n := &notes[i]
+		item := feed.Item{
+			ID:        n.ID,
+			Title:     n.Title,
+			Link:      s.site.noteURL(n.ID, n.Author.Username, n.Slug),
+			Author:    n.Author.Username,
+			Content:   s.content(n),
+			Published: n.CreatedAt,
+			Updated:   n.UpdatedAt,
+		}
+		if n.PublishedAt != nil {
+			item.Published = *n.PublishedAt
+		}
+		for _, t := range n.Tags {
+			item.Categories = append(item.Categories, t.Name)
+		}
+		if item.Updated.After(ch.Updated) {
+			ch.Updated = item.Updated
+		}
+		items = append(items, item)
+	}
+
+	out, err := feed.Build(format, ch, items)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(out)
+	return &FeedDocument{
+		XML:          string(out),
+		ETag:         `"` + hex.EncodeToString(sum[:8]) + `"`,
+		LastModified: ch.Updated,
+	}, nil
+}
+
+// content 返回条目内容：全文模式下为渲染并净化后的 HTML，渲染失败或摘要模式下为转义后的摘要段落。
+func (s *feedService) content(note *models.Note) string {
+	if s.fullContent {
+		if out, _, err := markdown.Render(note.Content); err == nil {
+			return out
+		}
+	}
+	summary := note.Summary
+	if summary == "" {
+		summary = markdown.Summary(note.Content, AutoSummaryLength)
+	}
+	return "<p>" + html.EscapeString(summary) + "</p>"
+}
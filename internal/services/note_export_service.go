@@ -0,0 +1,205 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"HYH-Blog-Gin/internal/markdown"
+	"HYH-Blog-Gin/internal/models"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// exportBatchSize 导出时每批从数据库读取的笔记数量
+const exportBatchSize = 100
+
+// NoteExportService 把用户的全部笔记导出为 Markdown 压缩包。
+type NoteExportService interface {
+	// Export 将 userID 的笔记（不含回收站）以 zip 格式流式写入 w；withImages 为 true 时同时打包正文与封面引用的本地图片
+	Export(userID uint, w io.Writer, withImages bool) error
+}
+
+type noteExportService struct {
+	notes   models.NoteRepository
+	storage Storage
+}
+
+// NewNoteExportService 创建 NoteExportService 实例，storage 用于定位需要打包的本地图片，可为 nil 表示不支持打包图片。
+func NewNoteExportService(notes models.NoteRepository, storage Storage) NoteExportService {
+	return &noteExportService{notes: notes, storage: storage}
+}
+
+// exportFrontMatter 导出文件的 YAML 前置元数据，字段与导入时识别的字段兼容（date、updated、tags、summary、cover、draft、unlisted）。
+type exportFrontMatter struct {
+	Title       string     `yaml:"title"`
+	Slug        string     `yaml:"slug,omitempty"`
+	Date        time.Time  `yaml:"date"`
+	Updated     time.Time  `yaml:"updated"`
+	PublishedAt *time.Time `yaml:"published_at,omitempty"`
+	PublishAt   *time.Time `yaml:"publish_at,omitempty"`
+	ExpiresAt   *time.Time `yaml:"expires_at,omitempty"`
+	Tags        []string   `yaml:"tags"`
+	Summary     string     `yaml:"summary,omitempty"`
+	Cover       string     `yaml:"cover,omitempty"`
+	Status      string     `yaml:"status"`
+	Public      bool       `yaml:"public"`
+	Unlisted    bool       `yaml:"unlisted,omitempty"`
+	Draft       bool       `yaml:"draft"`
+	Views       int64      `yaml:"views"`
+	Likes       int64      `yaml:"likes"`
+}
+
+// noteExport 一次导出过程的状态：已写入的文件名与已打包图片（URL → 压缩包内路径）。
+type noteExport struct {
+	*noteExportService
+	zw         *zip.Writer
+	withImages bool
+	names      map[string]struct{}
+	images     map[string]string
+}
+
+// Export 按 ID 顺序分批读取笔记并逐个写入压缩包，内存占用与笔记总数无关：
+// - 每篇笔记写为 notes/<slug>.md（无 slug 时为 notes/<id>.md），头部为 YAML 前置元数据；
+// - 打包图片时，能在存储中找到的图片写入 images/，正文与封面中的地址改写为相对路径 ../images/<文件名>。
+func (s *noteExportService) Export(userID uint, w io.Writer, withImages bool) error {
+	exp := &noteExport{noteExportService: s, zw: zip.NewWriter(w), withImages: withImages && s.storage != nil,
+		names: make(map[string]struct{}), images: make(map[string]string)}
+	err := s.notes.EachByAuthor(userID, exportBatchSize, func(notes []models.Note) error {
+		for i := range notes {
+			if err := exp.writeNote(&notes[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return exp.zw.Close()
+}
+
+// writeNote 写入单篇笔记（以及首次引用的图片）。
+func (exp *noteExport) writeNote(note *models.Note) error {
+	content, cover := note.Content, note.CoverImage
+	if exp.withImages {
+		urls := make(map[string]string)
+		for _, ref := range markdown.ImageRefs(content) {
+			if rel, ok, err := exp.bundleImage(ref); err != nil {
+				return err
+			} else if ok {
+				urls[ref] = rel
+			}
+		}
+		content = markdown.ReplaceImageRefs(content, urls)
+		if cover != "" {
+			rel, ok, err := exp.bundleImage(cover)
+			if err != nil {
+				return err
+			}
+			if ok {
+				cover = rel
+			}
+		}
+	}
+
+	fm := exportFrontMatter{
+		Title:       note.Title,
+		Slug:        note.Slug,
+		Date:        note.CreatedAt,
+		Updated:     note.UpdatedAt,
+		PublishedAt: note.PublishedAt,
+		PublishAt:   note.PublishAt,
+		ExpiresAt:   note.ExpiresAt,
+		Tags:        make([]string, 0, len(note.Tags)),
+		Summary:     note.Summary,
+		Cover:       cover,
+		Status:      note.Status,
+		Public:      note.IsPublic,
+		Unlisted:    note.Unlisted,
+		Draft:       note.Status != models.NoteStatusPublished,
+		Views:       note.Views,
+		Likes:       note.Likes,
+	}
+	for _, t := range note.Tags {
+		fm.Tags = append(fm.Tags, t.Name)
+	}
+	head, err := yaml.Marshal(&fm)
+	if err != nil {
+		return err
+	}
+
+	base := note.Slug
+	if base == "" {
+		base = strconv.FormatUint(uint64(note.ID), 10)
+	}
+	f, _, err := exp.create("notes/"+base+".md", zip.Deflate, note.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(head)
+	buf.WriteString("---\n\n")
+	buf.WriteString(content)
+	if !strings.HasSuffix(content, "\n") {
+		buf.WriteByte('\n')
+	}
+	_, err = buf.WriteTo(f)
+	return err
+}
+
+// bundleImage 把存储中的图片写入压缩包（同一图片只写一次），返回笔记文件中使用的相对路径；
+// 地址不属于本地存储或文件不存在时 ok 为 false，保留原地址。
+func (exp *noteExport) bundleImage(ref string) (rel string, ok bool, err error) {
+	if name, done := exp.images[ref]; done {
+		return "../" + name, true, nil
+	}
+	meta, err := exp.storage.GetImageInfo(ref)
+	if err != nil {
+		return "", false, nil
+	}
+	// 同一图片可能以不同写法引用
+	if name, done := exp.images[meta.URL]; done {
+		exp.images[ref] = name
+		return "../" + name, true, nil
+	}
+	src, err := os.Open(meta.Path)
+	if err != nil {
+		return "", false, nil
+	}
+	defer src.Close()
+
+	// 图片已是压缩格式，直接存储不再压缩
+	f, name, err := exp.create("images/"+path.Base(meta.URL), zip.Store, meta.ModTime)
+	if err != nil {
+		return "", false, err
+	}
+	if _, err := io.Copy(f, src); err != nil {
+		return "", false, fmt.Errorf("bundle image %s: %w", ref, err)
+	}
+	exp.images[ref] = name
+	exp.images[meta.URL] = name
+	return "../" + name, true, nil
+}
+
+// create 在压缩包中新建文件，名称已被占用时在扩展名前追加 -2、-3…，返回实际使用的名称。
+func (exp *noteExport) create(name string, method uint16, modified time.Time) (io.Writer, string, error) {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 2; ; i++ {
+		if _, taken := exp.names[name]; !taken {
+			break
+		}
+		name = fmt.Sprintf("%s-%d%s", stem, i, ext)
+	}
+	exp.names[name] = struct{}{}
+	w, err := exp.zw.CreateHeader(&zip.FileHeader{Name: name, Method: method, Modified: modified})
+	return w, name, err
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"HYH-Blog-Gin/internal/markdown"
+	"HYH-Blog-Gin/internal/models"
+	"HYH-Blog-Gin/internal/utils"
+)
+
+// 导入限制
+const (
+	MaxImportNotes     = 1000     // 单个压缩包最多导入的 Markdown 文件数
+	maxImportNoteSize  = 1 << 20  // 单个 Markdown 文件解压后的最大字节数
+	maxImportImageSize = 10 << 20 // 单张图片解压后的最大字节数，与图片上传接口一致
+)
+
+var ErrInvalidArchive = errors.New("invalid archive: expected a zip file containing up to 1000 .md files")
+
+// NoteImportFileResult 压缩包中单个 Markdown 文件的导入结果。
+// Images 为已上传（试运行时为将要上传）的本地图片数量，Warnings 记录未找到的图片等不影响导入的问题。
+type NoteImportFileResult struct {
+	File     string   `json:"file" example:"posts/hello-world.md"`
+	OK       bool     `json:"ok" example:"true"`
+	NoteID   uint     `json:"note_id,omitempty" example:"12"`
+	Title    string   `json:"title,omitempty" example:"Hello world"`
+	Status   string   `json:"status,omitempty" example:"published"`
+	Images   int      `json:"images" example:"2"`
+	Warnings []string `json:"warnings,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// NoteImportReport 导入报告，Files 按文件路径排序。
+type NoteImportReport struct {
+	DryRun   bool                   `json:"dry_run" example:"false"`
+	Imported int                    `json:"imported" example:"9"`
+	Failed   int                    `json:"failed" example:"1"`
+	Files    []NoteImportFileResult `json:"files"`
+}
+
+// NoteImportService 从 Hexo/Hugo 导出的 Markdown 压缩包批量导入笔记。
+type NoteImportService interface {
+	// Import 导入压缩包中的全部 .md 文件；dryRun 为 true 时只解析与校验，不写入笔记也不上传图片
+	Import(userID uint, archive io.ReaderAt, size int64, dryRun bool) (*NoteImportReport, error)
+}
+
+type noteImportService struct {
+	notes  models.NoteRepository
+	images ImageService
+}
+
+// NewNoteImportService 创建 NoteImportService 实例，images 用于上传正文与封面引用的本地图片。
+func NewNoteImportService(notes models.NoteRepository, images ImageService) NoteImportService {
+	return &noteImportService{notes: notes, images: images}
+}
+
+// noteImport 一次导入过程的状态：压缩包内的文件索引与已上传图片的 URL（同一图片只上传一次），
+// 以及当前文件新上传的图片（文件导入失败时删除，不留下孤立图片）。
+type noteImport struct {
+	*noteImportService
+	userID   uint
+	dryRun   bool
+	files    map[string]*zip.File
+	uploaded map[string]string
+	fresh    []string
+}
+
+// Import 逐个文件导入，每个文件独立成功或失败：
+// - 解析 YAML（---）或 TOML（+++）前置元数据中的 title、date、publish_at、expires_at、tags、summary、cover、draft、unlisted；
+// - 没有标题时使用文件名（页面包的 index.md 使用目录名）；date 作为创建时间保留；
+// - 未来的 publish_at（本站导出的定时发布笔记）或非草稿的未来 date 导入为定时发布，expires_at 对非草稿笔记保留；
+// - 正文与封面引用的本地图片（相对当前文件，或以 / 开头时相对压缩包根目录、static/、source/）上传后替换为新 URL；
+// - 文件导入失败时删除该文件新上传的图片。
+func (s *noteImportService) Import(userID uint, archive io.ReaderAt, size int64, dryRun bool) (*NoteImportReport, error) {
+	zr, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, ErrInvalidArchive
+	}
+	imp := &noteImport{noteImportService: s, userID: userID, dryRun: dryRun,
+		files: make(map[string]*zip.File, len(zr.File)), uploaded: make(map[string]string)}
+	var notes []string
+	for _, f := range zr.File {
+		name := path.Clean(strings.ReplaceAll(f.Name, "\\", "/"))
+		base := path.Base(name)
+		if f.FileInfo().IsDir() || strings.HasPrefix(name, "__MACOSX/") || strings.HasPrefix(base, ".") {
+			continue
+		}
+		imp.files[name] = f
+		if ext := strings.ToLower(path.Ext(name)); ext == ".md" || ext == ".markdown" {
+			notes = append(notes, name)
+		}
+	}
+	if len(notes) == 0 || len(notes) > MaxImportNotes {
+		return nil, ErrInvalidArchive
+	}
+	sort.Strings(notes)
+
+	report := &NoteImportReport{DryRun: dryRun, Files: make([]NoteImportFileResult, 0, len(notes))}
+	for _, name := range notes {
+		res := imp.importFile(name)
+		if res.OK {
+			report.Imported++
+		} else {
+			report.Failed++
+		}
+		report.Files = append(report.Files, res)
+	}
+	return report, nil
+}
+
+// importFile 导入单个 Markdown 文件。
+func (imp *noteImport) importFile(name string) NoteImportFileResult {
+	res := NoteImportFileResult{File: name}
+	imp.fresh = nil
+	fail := func(err error) NoteImportFileResult {
+		res.Error = err.Error()
+		res.Warnings = append(res.Warnings, imp.discardFresh()...)
+		return res
+	}
+
+	src, err := readZipFile(imp.files[name], maxImportNoteSize)
+	if err != nil {
+		return fail(err)
+	}
+	fm, body, err := markdown.ParseFrontMatter(src)
+	if err != nil {
+		return fail(err)
+	}
+	if strings.TrimSpace(body) == "" {
+		return fail(errors.New("empty content"))
+	}
+
+	now := time.Now()
+	note := &models.Note{AuthorID: imp.userID, Title: fm.Title, Status: models.NoteStatusDraft, Unlisted: fm.Unlisted}
+	if note.Title == "" {
+		note.Title = strings.TrimSuffix(path.Base(name), path.Ext(name))
+		// Hugo 页面包（posts/hello/index.md）使用目录名
+		if (note.Title == "index" || note.Title == "_index") && path.Dir(name) != "." {
+			note.Title = path.Base(path.Dir(name))
+		}
+	}
+	switch {
+	// 本站导出的定时发布笔记带 draft: true，以 publish_at 为准
+	case fm.PublishAt != nil && fm.PublishAt.After(now):
+		note.Status = models.NoteStatusScheduled
+		note.PublishAt = fm.PublishAt
+	case fm.Draft:
+	case fm.Date != nil && fm.Date.After(now):
+		note.Status = models.NoteStatusScheduled
+		note.PublishAt = fm.Date
+	default:
+		note.Status = models.NoteStatusPublished
+		note.IsPublic = true
+		note.PublishedAt = &now
+		if fm.Date != nil {
+			note.PublishedAt = fm.Date
+		}
+	}
+	// 过期时间须晚于发布时间，否则忽略；已过去的过期时间原样保留，导入后仍为过期状态
+	if fm.ExpiresAt != nil && note.Status != models.NoteStatusDraft {
+		if note.PublishAt != nil && !fm.ExpiresAt.After(*note.PublishAt) {
+			res.Warnings = append(res.Warnings, "expires_at is not after publish_at, ignored")
+		} else {
+			note.ExpiresAt = fm.ExpiresAt
+		}
+	}
+	// 保留原始的创建/更新时间（未来的日期除外），零值时由 GORM 填充当前时间
+	if fm.Date != nil && !fm.Date.After(now) {
+		note.CreatedAt = *fm.Date
+		note.UpdatedAt = *fm.Date
+		if fm.Updated != nil && fm.Updated.After(*fm.Date) && !fm.Updated.After(now) {
+			note.UpdatedAt = *fm.Updated
+		}
+	}
+
+	// 上传本地图片并改写地址
+	dir := path.Dir(name)
+	urls := make(map[string]string)
+	for _, ref := range markdown.ImageRefs(body) {
+		url, ok, err := imp.localImage(dir, ref)
+		if err != nil {
+			return fail(err)
+		}
+		if !ok {
+			if isLocalRef(ref) {
+				res.Warnings = append(res.Warnings, "image not found in archive: "+ref)
+			}
+			continue
+		}
+		urls[ref] = url
+		res.Images++
+	}
+	note.Content = markdown.ReplaceImageRefs(body, urls)
+
+	if fm.Cover != "" {
+		url, ok, err := imp.localImage(dir, fm.Cover)
+		if err != nil {
+			return fail(err)
+		}
+		if ok {
+			note.CoverImage = url
+			if _, seen := urls[fm.Cover]; !seen {
+				res.Images++
+			}
+		} else {
+			res.Warnings = append(res.Warnings, "cover is not an image in the archive, ignored: "+fm.Cover)
+		}
+	}
+
+	note.Summary = fm.Summary
+	if note.Summary == "" {
+		note.Summary = markdown.Summary(note.Content, AutoSummaryLength)
+	}
+
+	res.Title = note.Title
+	res.Status = note.Status
+	if !imp.dryRun {
+		if err := imp.notes.CreateWithTags(note, fm.Tags); err != nil {
+			return fail(err)
+		}
+		res.NoteID = note.ID
+	}
+	res.OK = true
+	return res
+}
+
+// localImage 在压缩包中查找本地图片并上传（试运行时只校验），返回新的 URL；
+// 引用的不是压缩包中的文件时 ok 为 false，文件不是图片或上传失败时返回错误。
+func (imp *noteImport) localImage(dir, ref string) (saved string, ok bool, err error) {
+	if !isLocalRef(ref) {
+		return "", false, nil
+	}
+	clean := ref
+	if i := strings.IndexAny(clean, "?#"); i >= 0 {
+		clean = clean[:i]
+	}
+	if unescaped, err := url.PathUnescape(clean); err == nil {
+		clean = unescaped
+	}
+	var candidates []string
+	if strings.HasPrefix(clean, "/") {
+		p := strings.TrimPrefix(path.Clean(clean), "/")
+		candidates = []string{p, "static/" + p, "source/" + p}
+	} else {
+		candidates = []string{path.Join(dir, clean)}
+	}
+	var name string
+	for _, c := range candidates {
+		if _, found := imp.files[c]; found {
+			name = c
+			break
+		}
+	}
+	if name == "" {
+		return "", false, nil
+	}
+	if saved, done := imp.uploaded[name]; done {
+		return saved, true, nil
+	}
+
+	data, err := readZipFile(imp.files[name], maxImportImageSize)
+	if err != nil {
+		return "", false, err
+	}
+	if ct := http.DetectContentType(data); !utils.IsAllowedImageContentType(ct) {
+		return "", false, fmt.Errorf("%s: unsupported file type: %s", name, ct)
+	}
+	saved = ref
+	if !imp.dryRun {
+		if saved, err = imp.images.Save(data, utils.SanitizeFileName(path.Base(name))); err != nil {
+			return "", false, fmt.Errorf("upload image %s: %w", name, err)
+		}
+	}
+	imp.uploaded[name] = saved
+	if !imp.dryRun {
+		imp.fresh = append(imp.fresh, name)
+	}
+	return saved, true, nil
+}
+
+// discardFresh 删除当前文件新上传的图片（其他文件已引用的图片不受影响），返回删除失败的警告。
+func (imp *noteImport) discardFresh() []string {
+	var warnings []string
+	for _, name := range imp.fresh {
+		if err := imp.images.DeleteImage(imp.uploaded[name]); err != nil {
+			warnings = append(warnings, "failed to remove uploaded image "+name+": "+err.Error())
+		}
+		delete(imp.uploaded, name)
+	}
+	imp.fresh = nil
+	return warnings
+}
+
+// isLocalRef 判断图片地址是否指向本地文件（不是 URL、协议相对地址或 data URI）。
+func isLocalRef(ref string) bool {
+	lower := strings.ToLower(ref)
+	return ref != "" && !strings.HasPrefix(ref, "//") && !strings.Contains(lower, "://") && !strings.HasPrefix(lower, "data:")
+}
+
+// readZipFile 读取压缩包中的文件，解压后超过 limit 字节时返回错误（防止压缩炸弹）。
+func readZipFile(f *zip.File, limit int64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.Name, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.Name, err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s: file too large", f.Name)
+	}
+	return data, nil
+}
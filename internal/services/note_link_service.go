@@ -0,0 +1,67 @@
+package services
+
+import (
+	"time"
+
+	"HYH-Blog-Gin/internal/models"
+)
+
+// NoteLinkService 提供笔记之间的出链与反向链接查询，笔记可见性规则与读取笔记一致。
+type NoteLinkService interface {
+	// Links 列出笔记正文中引用的笔记，Exists 标记目标是否仍存在
+	Links(userID, noteID uint) ([]models.NoteLinkItem, error)
+	// Backlinks 列出引用该笔记且对请求者可见的笔记
+	Backlinks(userID, noteID uint) ([]models.NoteLinkItem, error)
+}
+
+type noteLinkService struct {
+	notes models.NoteRepository
+	links models.NoteLinkRepository
+}
+
+// NewNoteLinkService 创建 NoteLinkService 实例。
+func NewNoteLinkService(notes models.NoteRepository, links models.NoteLinkRepository) NoteLinkService {
+	return &noteLinkService{notes: notes, links: links}
+}
+
+// visibleNote 获取对请求者可见的笔记，userID 为 0 表示匿名访问。
+func (s *noteLinkService) visibleNote(userID, noteID uint) (*models.Note, error) {
+	note, err := s.notes.FindByID(noteID)
+	if err != nil || note == nil || note.ID == 0 {
+		return nil, ErrNotFound
+	}
+	if !note.IsPubliclyVisible(time.Now()) && note.AuthorID != userID {
+		return nil, ErrForbidden
+	}
+	return note, nil
+}
+
+// Links 列出出链；目标存在但对请求者不可见时只保留 ID，不返回标题与 slug。
+func (s *noteLinkService) Links(userID, noteID uint) ([]models.NoteLinkItem, error) {
+	note, err := s.visibleNote(userID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.links.Outgoing(note)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range items {
+		it := &items[i]
+		if it.Exists && it.AuthorID != userID && !it.IsPubliclyVisible(now) {
+			it.Title = ""
+			it.Slug = ""
+		}
+	}
+	return items, nil
+}
+
+// Backlinks 列出反向链接。
+func (s *noteLinkService) Backlinks(userID, noteID uint) ([]models.NoteLinkItem, error) {
+	note, err := s.visibleNote(userID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	return s.links.Backlinks(note, userID)
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"HYH-Blog-Gin/internal/models"
+	"HYH-Blog-Gin/internal/utils"
+)
+
+// NoteRevisionDiff 两个修订版本之间的差异：标题/摘要/正文为行级差异，标签为集合差异。
+type NoteRevisionDiff struct {
+	NoteID      uint             `json:"note_id"`
+	From        int              `json:"from"`
+	To          int              `json:"to"`
+	Title       []utils.DiffLine `json:"title"`
+	Summary     []utils.DiffLine `json:"summary"`
+	Content     []utils.DiffLine `json:"content"`
+	TagsAdded   []string         `json:"tags_added"`
+	TagsRemoved []string         `json:"tags_removed"`
+}
+
+// NoteRevisionService 提供笔记修订历史的查询、对比与恢复，仅笔记作者可访问。
+type NoteRevisionService interface {
+	List(userID, noteID uint, page, limit int) ([]models.NoteRevision, int64, error)
+	Get(userID, noteID uint, version int) (*models.NoteRevision, error)
+	Diff(userID, noteID uint, from, to int) (*NoteRevisionDiff, error)
+	Restore(userID, noteID uint, version int) (*models.Note, error)
+}
+
+type noteRevisionService struct {
+	notes     models.NoteRepository
+	revisions models.NoteRevisionRepository
+}
+
+// NewNoteRevisionService 创建 NoteRevisionService 实例。
+func NewNoteRevisionService(notes models.NoteRepository, revisions models.NoteRevisionRepository) NoteRevisionService {
+	return &noteRevisionService{notes: notes, revisions: revisions}
+}
+
+// ownedNote 获取笔记并校验作者身份。
+func (s *noteRevisionService) ownedNote(userID, noteID uint) (*models.Note, error) {
+	note, err := s.notes.FindByID(noteID)
+	if err != nil || note == nil || note.ID == 0 {
+		return nil, ErrNotFound
+	}
+	if note.AuthorID != userID {
+		return nil, ErrForbidden
+	}
+	return note, nil
+}
+
+// List 分页列出笔记的修订记录（版本号倒序）。
+func (s *noteRevisionService) List(userID, noteID uint, page, limit int) ([]models.NoteRevision, int64, error) {
+	if _, err := s.ownedNote(userID, noteID); err != nil {
+		return nil, 0, err
+	}
+	return s.revisions.ListByNote(noteID, page, limit)
+}
+
+// Get 获取指定版本的修订记录。
+func (s *noteRevisionService) Get(userID, noteID uint, version int) (*models.NoteRevision, error) {
+	if _, err := s.ownedNote(userID, noteID); err != nil {
+		return nil, err
+	}
+	rev, err := s.revisions.FindByVersion(noteID, version)
+	if err != nil || rev == nil || rev.ID == 0 {
+		return nil, ErrNotFound
+	}
+	return rev, nil
+}
+
+// Diff 对比两个版本（from → to）。
+func (s *noteRevisionService) Diff(userID, noteID uint, from, to int) (*NoteRevisionDiff, error) {
+	a, err := s.Get(userID, noteID, from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.Get(userID, noteID, to)
+	if err != nil {
+		return nil, err
+	}
+	diff := &NoteRevisionDiff{
+		NoteID:      noteID,
+		From:        from,
+		To:          to,
+		Title:       utils.DiffLines(a.Title, b.Title),
+		Summary:     utils.DiffLines(a.Summary, b.Summary),
+		Content:     utils.DiffLines(a.Content, b.Content),
+		TagsAdded:   []string{},
+		TagsRemoved: []string{},
+	}
+	inA := make(map[string]struct{}, len(a.TagNames))
+	for _, t := range a.TagNames {
+		inA[t] = struct{}{}
+	}
+	inB := make(map[string]struct{}, len(b.TagNames))
+	for _, t := range b.TagNames {
+		inB[t] = struct{}{}
+		if _, ok := inA[t]; !ok {
+			diff.TagsAdded = append(diff.TagsAdded, t)
+		}
+	}
+	for _, t := range a.TagNames {
+		if _, ok := inB[t]; !ok {
+			diff.TagsRemoved = append(diff.TagsRemoved, t)
+		}
+	}
+	return diff, nil
+}
+
+// Restore 将笔记恢复为指定版本的内容；恢复本身作为一次新的更新，会产生新的修订记录。
+func (s *noteRevisionService) Restore(userID, noteID uint, version int) (*models.Note, error) {
+	note, err := s.ownedNote(userID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	rev, err := s.revisions.FindByVersion(noteID, version)
+	if err != nil || rev == nil || rev.ID == 0 {
+		return nil, ErrNotFound
+	}
+	note.Title = rev.Title
+	note.Summary = rev.Summary
+	note.Content = rev.Content
+	// 非 nil 切片表示替换标签集合（空切片即清空）
+	tags := append([]string{}, rev.TagNames...)
+	if err := s.notes.UpdateWithTags(note, tags); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
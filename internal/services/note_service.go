@@ -2,66 +2,205 @@ package services
 
 import (
 	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
 
+	"HYH-Blog-Gin/internal/markdown"
 	"HYH-Blog-Gin/internal/models"
 )
 
 var (
-	ErrNotFound  = errors.New("not found")
-	ErrForbidden = errors.New("forbidden")
+	ErrNotFound          = errors.New("not found")
+	ErrForbidden         = errors.New("forbidden")
+	ErrInvalidStatus     = errors.New("invalid status")
+	ErrInvalidVisibility = errors.New("invalid visibility: must be public, unlisted or private and consistent with public/status")
+	ErrInvalidSchedule   = errors.New("invalid schedule: publish_at/expires_at must be in the future and expires_at after publish_at")
+	ErrInvalidCover      = errors.New("cover_image does not refer to an uploaded image")
+	ErrVersionRequired   = errors.New("version is required to update a note")
+	ErrInvalidBulk       = errors.New("invalid bulk request: ids must contain 1-500 note ids and action must be delete, restore, set_visibility (with is_public), add_tags, remove_tags (with tags) or replace_tags")
+	// ErrVersionConflict 与仓储层的 models.ErrNoteVersionConflict 为同一错误，便于直接透传
+	ErrVersionConflict = models.ErrNoteVersionConflict
 )
 
+// AutoSummaryLength 自动生成摘要的最大字符数
+const AutoSummaryLength = 140
+
+// MaxBulkNotes 单次批量操作最多处理的笔记数量
+const MaxBulkNotes = 500
+
+// 相关笔记推荐：从按标签相似度排序的前 relatedCandidates 篇候选中按综合得分取前若干篇。
+// 得分 = 标签 Jaccard 相似度 × relatedWeightTags + 新近度 × relatedWeightRecency + 热度 × relatedWeightPopularity，
+// 新近度按发布时间以 relatedHalfLife 为半衰期衰减，热度为 log(1+阅读量+点赞数×relatedLikeWeight) 相对候选中最大值的比例。
+const (
+	MaxRelatedNotes         = 20
+	relatedCandidates       = 50
+	relatedWeightTags       = 0.6
+	relatedWeightRecency    = 0.25
+	relatedWeightPopularity = 0.15
+	relatedLikeWeight       = 5
+	relatedHalfLife         = 90 * 24 * time.Hour
+)
+
+// NoteInput 创建/更新笔记的输入。指针字段为 nil 表示未提供（更新时保持不变），Tags 为 nil 表示不修改标签。
+// 发布状态可以直接通过 Status 指定，也可以使用兼容的 IsPublic（true=published，false=draft/unpublished）；
+// 仅提供 PublishAt 时视为定时发布。
+// Visibility 为 public/unlisted/private：public 与 unlisted 在未指定 Status/PublishAt 时立即发布，private 等同 IsPublic=false。
+// Summary 为空字符串时根据正文自动生成摘要；CoverImage 为空字符串时清除封面。
+// Version 为客户端读取时的版本号，更新时必填，与当前版本不一致时返回 ErrVersionConflict。
+type NoteInput struct {
+	Title      *string
+	Content    *string
+	Summary    *string
+	CoverImage *string
+	Tags       []string
+	IsPublic   *bool
+	Visibility *string
+	Status     *string
+	PublishAt  *time.Time
+	ExpiresAt  *time.Time
+	Version    *int64
+}
+
+// NoteBulkInput 批量操作的输入：Action 为 models.NoteBulk* 常量；
+// IsPublic 在 set_visibility 时必填，Tags 在 add_tags/remove_tags 时必须非空，replace_tags 时为空表示清空标签。
+type NoteBulkInput struct {
+	IDs      []uint
+	Action   string
+	IsPublic *bool
+	Tags     []string
+}
+
 // NoteService 抽象了笔记相关的业务逻辑。
 type NoteService interface {
 	GetNotes(userID uint, page, limit int) ([]models.Note, int64, error)
-	CreateNote(userID uint, title, content string, tags []string, isPublic *bool) (*models.Note, error)
+	CreateNote(userID uint, in NoteInput) (*models.Note, error)
 	GetNoteByID(userID, id uint) (*models.Note, error)
-	UpdateNote(userID, id uint, title, content *string, tags []string, isPublic *bool) (*models.Note, error)
+	UpdateNote(userID, id uint, in NoteInput) (*models.Note, error)
 	DeleteNote(userID, id uint) error
+	ListTrash(userID uint, page, limit int) ([]models.Note, int64, error)
+	RestoreNote(userID, id uint) (*models.Note, error)
+	PurgeNote(userID, id uint) error
+	BulkNotes(userID uint, in NoteBulkInput) ([]models.NoteBulkResult, error)
+	GetPublicNotes(filter models.PublicNoteFilter, page, limit int) ([]models.Note, int64, error)
+	GetPublicNoteByID(id uint) (*models.Note, error)
+	GetNoteBySlug(userID uint, username, slug string) (*models.Note, error)
+	LikeNote(userID, id uint) (bool, error)
+	UnlikeNote(userID, id uint) (bool, error)
+	Search(userID uint, opts models.NoteSearchOptions, page, limit int) ([]models.Note, int64, error)
+	// RelatedNotes 为请求者可见的笔记推荐最多 limit 篇相关的公开笔记
+	RelatedNotes(userID, id uint, limit int) ([]models.RelatedNote, error)
 }
 
 // noteService 是 NoteService 的默认实现，封装 repositories。
 type noteService struct {
-	notes models.NoteRepository
+	notes     models.NoteRepository
+	images    models.ImageRepository
+	likes     models.NoteLikeRepository
+	bookmarks models.BookmarkRepository
+	series    models.SeriesRepository
 }
 
-// NewNoteService 创建 NoteService 实例。
-func NewNoteService(notes models.NoteRepository) NoteService {
-	return &noteService{notes: notes}
+// NewNoteService 创建 NoteService 实例，images 用于校验封面图片是否存在，likes 与 bookmarks 用于填充请求者的点赞/收藏状态，
+// series 用于填充单条笔记的系列上下文。
+func NewNoteService(notes models.NoteRepository, images models.ImageRepository, likes models.NoteLikeRepository, bookmarks models.BookmarkRepository, series models.SeriesRepository) NoteService {
+	return &noteService{notes: notes, images: images, likes: likes, bookmarks: bookmarks, series: series}
+}
+
+// markViewerState 为笔记填充请求者相关的 LikedByMe、BookmarkedByMe；匿名请求一律为 false，查询失败时不填充对应字段。
+func (s *noteService) markViewerState(userID uint, notes ...*models.Note) {
+	ids := make([]uint, 0, len(notes))
+	for _, n := range notes {
+		ids = append(ids, n.ID)
+	}
+	if liked, err := s.likes.LikedNoteIDs(userID, ids); err == nil {
+		for _, n := range notes {
+			v := liked[n.ID]
+			n.LikedByMe = &v
+		}
+	}
+	if marked, err := s.bookmarks.BookmarkedNoteIDs(userID, ids); err == nil {
+		for _, n := range notes {
+			v := marked[n.ID]
+			n.BookmarkedByMe = &v
+		}
+	}
+}
+
+// markViewerStateList 为列表中的笔记填充请求者相关状态。
+func (s *noteService) markViewerStateList(userID uint, notes []models.Note) {
+	ptrs := make([]*models.Note, 0, len(notes))
+	for i := range notes {
+		ptrs = append(ptrs, &notes[i])
+	}
+	s.markViewerState(userID, ptrs...)
 }
 
 // GetNotes 分页获取指定用户的笔记列表。
 func (s *noteService) GetNotes(userID uint, page, limit int) ([]models.Note, int64, error) {
-	return s.notes.FindByAuthor(userID, page, limit)
+	notes, total, err := s.notes.FindByAuthor(userID, page, limit)
+	if err == nil {
+		s.markViewerStateList(userID, notes)
+	}
+	return notes, total, err
 }
 
-// CreateNote 创建新笔记。
-func (s *noteService) CreateNote(userID uint, title, content string, tags []string, isPublic *bool) (*models.Note, error) {
-	note := &models.Note{Title: title, Content: content, AuthorID: userID}
-	if isPublic != nil {
-		note.IsPublic = *isPublic
+// CreateNote 创建新笔记，未指定发布状态时为草稿。
+func (s *noteService) CreateNote(userID uint, in NoteInput) (*models.Note, error) {
+	note := &models.Note{AuthorID: userID, Status: models.NoteStatusDraft}
+	if in.Title != nil {
+		note.Title = *in.Title
+	}
+	if in.Content != nil {
+		note.Content = *in.Content
+	}
+	if err := s.applySummaryAndCover(note, in, ""); err != nil {
+		return nil, err
+	}
+	if err := applyVisibility(note, &in); err != nil {
+		return nil, err
+	}
+	if err := applyLifecycle(note, in, time.Now()); err != nil {
+		return nil, err
 	}
-	if err := s.notes.CreateWithTags(note, tags); err != nil {
+	if err := s.notes.CreateWithTags(note, in.Tags); err != nil {
 		return nil, err
 	}
 	return note, nil
 }
 
 // GetNoteByID 根据 ID 获取笔记，若笔记非公开且非作者则返回 forbidden。
+// userID 为 0 表示匿名访问，此时只能读取公开笔记；非作者读取时隐去作者邮箱。
 func (s *noteService) GetNoteByID(userID, id uint) (*models.Note, error) {
 	note, err := s.notes.FindByID(id)
 	if err != nil || note == nil || note.ID == 0 {
 		return nil, ErrNotFound
 	}
-	// 如果笔记不是公开且不是作者，返回 forbidden
-	if !note.IsPublic && note.AuthorID != userID {
+	// 如果笔记不是公开（或已过期）且不是作者，返回 forbidden
+	if !note.IsPubliclyVisible(time.Now()) && note.AuthorID != userID {
 		return nil, ErrForbidden
 	}
+	if note.AuthorID != userID {
+		note = withoutAuthorEmail(note)
+	}
+	s.markViewerState(userID, note)
+	note.Series = seriesContext(s.series, userID, note)
 	return note, nil
 }
 
-// UpdateNote 更新笔记，只有作者可更新。
-func (s *noteService) UpdateNote(userID, id uint, title, content *string, tags []string, isPublic *bool) (*models.Note, error) {
+// withoutAuthorEmail 返回隐去作者邮箱的笔记副本，与公开接口的 publicAuthor 投影一致；不修改可能来自缓存的原对象。
+func withoutAuthorEmail(note *models.Note) *models.Note {
+	n := *note
+	n.Author.Email = ""
+	return &n
+}
+
+// UpdateNote 更新笔记，只有作者可更新；in.Version 必须与当前版本一致（乐观锁）。
+func (s *noteService) UpdateNote(userID, id uint, in NoteInput) (*models.Note, error) {
+	if in.Version == nil {
+		return nil, ErrVersionRequired
+	}
 	note, err := s.notes.FindByID(id)
 	if err != nil || note == nil || note.ID == 0 {
 		return nil, ErrNotFound
@@ -69,21 +208,157 @@ func (s *noteService) UpdateNote(userID, id uint, title, content *string, tags [
 	if note.AuthorID != userID {
 		return nil, ErrForbidden
 	}
-	if title != nil {
-		note.Title = *title
+	// 提前拒绝明显过期的版本；并发写入由仓储在事务内按行锁再次校验
+	if note.Version != *in.Version {
+		return nil, ErrVersionConflict
+	}
+	prevContent := note.Content
+	if in.Title != nil {
+		note.Title = *in.Title
 	}
-	if content != nil {
-		note.Content = *content
+	if in.Content != nil {
+		note.Content = *in.Content
 	}
-	if isPublic != nil {
-		note.IsPublic = *isPublic
+	if err := s.applySummaryAndCover(note, in, prevContent); err != nil {
+		return nil, err
 	}
-	if err := s.notes.UpdateWithTags(note, tags); err != nil {
+	if err := applyVisibility(note, &in); err != nil {
+		return nil, err
+	}
+	if err := applyLifecycle(note, in, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.notes.UpdateWithTags(note, in.Tags); err != nil {
 		return nil, err
 	}
 	return note, nil
 }
 
+// applySummaryAndCover 写入摘要与封面：
+// - 显式提供的摘要直接使用，为空时由正文自动生成；
+// - 未提供摘要且原摘要是由旧正文自动生成的，正文变化后随之重新生成，手写摘要保持不变；
+// - 非空封面必须是已上传图片的 URL。
+func (s *noteService) applySummaryAndCover(note *models.Note, in NoteInput, prevContent string) error {
+	if in.Summary != nil {
+		note.Summary = strings.TrimSpace(*in.Summary)
+	} else if note.ID != 0 && note.Content != prevContent && note.Summary == markdown.Summary(prevContent, AutoSummaryLength) {
+		note.Summary = ""
+	}
+	if note.Summary == "" {
+		note.Summary = markdown.Summary(note.Content, AutoSummaryLength)
+	}
+
+	if in.CoverImage != nil {
+		cover := strings.TrimSpace(*in.CoverImage)
+		if cover != "" {
+			img, err := s.images.FindByURL(cover)
+			if err != nil || img == nil || img.ID == 0 {
+				return ErrInvalidCover
+			}
+		}
+		note.CoverImage = cover
+	}
+	return nil
+}
+
+// applyVisibility 把 Visibility 换算为 Unlisted 与 IsPublic，供随后的 applyLifecycle 推导发布状态：
+// - public/unlisted 设置 Unlisted；未指定 Status 与 PublishAt 时视为 IsPublic=true（立即发布），已定时发布的笔记保持定时；
+// - private 视为 IsPublic=false，Unlisted 保持不变；
+// - 与同时提供的 IsPublic 或 Status 矛盾时返回 ErrInvalidVisibility。
+func applyVisibility(note *models.Note, in *NoteInput) error {
+	if in.Visibility == nil {
+		return nil
+	}
+	switch *in.Visibility {
+	case models.NoteVisibilityPublic, models.NoteVisibilityUnlisted:
+		if in.IsPublic != nil && !*in.IsPublic {
+			return ErrInvalidVisibility
+		}
+		note.Unlisted = *in.Visibility == models.NoteVisibilityUnlisted
+		if in.Status == nil && in.PublishAt == nil && note.Status != models.NoteStatusScheduled {
+			public := true
+			in.IsPublic = &public
+		}
+	case models.NoteVisibilityPrivate:
+		if in.IsPublic != nil && *in.IsPublic {
+			return ErrInvalidVisibility
+		}
+		if in.Status != nil && (*in.Status == models.NoteStatusPublished || *in.Status == models.NoteStatusScheduled) {
+			return ErrInvalidVisibility
+		}
+		public := false
+		in.IsPublic = &public
+	default:
+		return ErrInvalidVisibility
+	}
+	return nil
+}
+
+// applyLifecycle 根据输入推导并校验笔记的发布状态，同步 IsPublic、PublishAt、PublishedAt 与 ExpiresAt。
+func applyLifecycle(note *models.Note, in NoteInput, now time.Time) error {
+	// 更新时未涉及发布相关字段，保持现状
+	if note.ID != 0 && in.Status == nil && in.IsPublic == nil && in.PublishAt == nil && in.ExpiresAt == nil {
+		return nil
+	}
+	status := note.Status
+	switch {
+	case in.Status != nil:
+		status = *in.Status
+	case in.IsPublic != nil && *in.IsPublic:
+		status = models.NoteStatusPublished
+	case in.IsPublic != nil:
+		// 取消公开：已发布的笔记变为下线，其余保持原状态（定时发布同时取消）
+		if status == models.NoteStatusPublished {
+			status = models.NoteStatusUnpublished
+		} else if status == models.NoteStatusScheduled {
+			status = models.NoteStatusDraft
+		}
+	case in.PublishAt != nil:
+		status = models.NoteStatusScheduled
+	}
+	if status == "" {
+		status = models.NoteStatusDraft
+	}
+
+	if in.ExpiresAt != nil {
+		if !in.ExpiresAt.After(now) {
+			return ErrInvalidSchedule
+		}
+		note.ExpiresAt = in.ExpiresAt
+	}
+
+	switch status {
+	case models.NoteStatusDraft, models.NoteStatusUnpublished:
+		note.PublishAt = nil
+	case models.NoteStatusScheduled:
+		publishAt := note.PublishAt
+		if in.PublishAt != nil {
+			publishAt = in.PublishAt
+		}
+		if publishAt == nil || !publishAt.After(now) {
+			return ErrInvalidSchedule
+		}
+		if note.ExpiresAt != nil && !note.ExpiresAt.After(*publishAt) {
+			return ErrInvalidSchedule
+		}
+		note.PublishAt = publishAt
+	case models.NoteStatusPublished:
+		note.PublishAt = nil
+		if note.PublishedAt == nil {
+			note.PublishedAt = &now
+		}
+		// 重新发布已过期的笔记时，清除旧的到期时间
+		if in.ExpiresAt == nil && note.ExpiresAt != nil && !note.ExpiresAt.After(now) {
+			note.ExpiresAt = nil
+		}
+	default:
+		return ErrInvalidStatus
+	}
+	note.Status = status
+	note.IsPublic = status == models.NoteStatusPublished
+	return nil
+}
+
 // DeleteNote 删除笔记，只有作者可删除。
 func (s *noteService) DeleteNote(userID, id uint) error {
 	note, err := s.notes.FindByID(id)
@@ -95,3 +370,193 @@ func (s *noteService) DeleteNote(userID, id uint) error {
 	}
 	return s.notes.Delete(id)
 }
+
+// ListTrash 分页列出当前用户回收站中的笔记。
+func (s *noteService) ListTrash(userID uint, page, limit int) ([]models.Note, int64, error) {
+	return s.notes.FindTrashed(userID, page, limit)
+}
+
+// trashedNote 获取回收站中的笔记并校验作者身份。
+func (s *noteService) trashedNote(userID, id uint) (*models.Note, error) {
+	note, err := s.notes.FindTrashedByID(id)
+	if err != nil || note == nil || note.ID == 0 {
+		return nil, ErrNotFound
+	}
+	if note.AuthorID != userID {
+		return nil, ErrForbidden
+	}
+	return note, nil
+}
+
+// RestoreNote 从回收站恢复笔记，返回恢复后的笔记。
+func (s *noteService) RestoreNote(userID, id uint) (*models.Note, error) {
+	if _, err := s.trashedNote(userID, id); err != nil {
+		return nil, err
+	}
+	if err := s.notes.Restore(id); err != nil {
+		return nil, err
+	}
+	return s.GetNoteByID(userID, id)
+}
+
+// PurgeNote 彻底删除回收站中的笔记，不可恢复。
+func (s *noteService) PurgeNote(userID, id uint) error {
+	if _, err := s.trashedNote(userID, id); err != nil {
+		return err
+	}
+	return s.notes.Purge(id)
+}
+
+// BulkNotes 对当前用户的一组笔记执行批量操作，返回逐条结果；他人的笔记与不存在的笔记记为失败，不影响其余笔记。
+func (s *noteService) BulkNotes(userID uint, in NoteBulkInput) ([]models.NoteBulkResult, error) {
+	// 去重并保持顺序
+	ids := make([]uint, 0, len(in.IDs))
+	seen := make(map[uint]struct{}, len(in.IDs))
+	for _, id := range in.IDs {
+		if _, ok := seen[id]; id == 0 || ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 || len(ids) > MaxBulkNotes {
+		return nil, ErrInvalidBulk
+	}
+
+	op := models.NoteBulkOp{Action: in.Action}
+	switch in.Action {
+	case models.NoteBulkDelete, models.NoteBulkRestore:
+	case models.NoteBulkSetVisibility:
+		if in.IsPublic == nil {
+			return nil, ErrInvalidBulk
+		}
+		op.IsPublic = *in.IsPublic
+	case models.NoteBulkAddTags, models.NoteBulkRemoveTags, models.NoteBulkReplaceTags:
+		op.Tags = make([]string, 0, len(in.Tags))
+		for _, t := range in.Tags {
+			if t = strings.TrimSpace(t); t != "" {
+				op.Tags = append(op.Tags, t)
+			}
+		}
+		if len(op.Tags) == 0 && in.Action != models.NoteBulkReplaceTags {
+			return nil, ErrInvalidBulk
+		}
+	default:
+		return nil, ErrInvalidBulk
+	}
+	return s.notes.Bulk(userID, ids, op)
+}
+
+// GetPublicNotes 分页获取公开笔记（无需登录），可按作者与标签过滤。
+func (s *noteService) GetPublicNotes(filter models.PublicNoteFilter, page, limit int) ([]models.Note, int64, error) {
+	return s.notes.FindPublic(filter, page, limit)
+}
+
+// GetPublicNoteByID 获取单条公开笔记；不存在或非公开均返回 not found，避免暴露私有笔记是否存在。
+func (s *noteService) GetPublicNoteByID(id uint) (*models.Note, error) {
+	note, err := s.notes.FindPublicByID(id)
+	if err != nil || note == nil || note.ID == 0 {
+		return nil, ErrNotFound
+	}
+	note.Series = seriesContext(s.series, 0, note)
+	return note, nil
+}
+
+// GetNoteBySlug 按作者用户名与 slug（含历史 slug）获取笔记。userID 为 0 表示匿名访问。
+// 非作者只能读取公开且未过期的笔记，其余情况均返回 not found，避免暴露私有笔记的存在与当前 slug。
+func (s *noteService) GetNoteBySlug(userID uint, username, slug string) (*models.Note, error) {
+	note, err := s.notes.FindBySlug(username, slug)
+	if err != nil || note == nil || note.ID == 0 {
+		return nil, ErrNotFound
+	}
+	if note.AuthorID != userID && !note.IsPubliclyVisible(time.Now()) {
+		return nil, ErrNotFound
+	}
+	s.markViewerState(userID, note)
+	note.Series = seriesContext(s.series, userID, note)
+	return note, nil
+}
+
+// LikeNote 点赞笔记（仅限请求者可见的笔记），每个用户对同一笔记最多点赞一次。
+// 返回是否为新增点赞；重复点赞不报错，返回 false。
+func (s *noteService) LikeNote(userID, id uint) (bool, error) {
+	note, err := s.notes.FindByID(id)
+	if err != nil || note == nil || note.ID == 0 {
+		return false, ErrNotFound
+	}
+	if !note.IsPubliclyVisible(time.Now()) && note.AuthorID != userID {
+		return false, ErrForbidden
+	}
+	return s.likes.Like(id, userID)
+}
+
+// UnlikeNote 取消点赞，幂等：未点赞时返回 false。
+func (s *noteService) UnlikeNote(userID, id uint) (bool, error) {
+	note, err := s.notes.FindByID(id)
+	if err != nil || note == nil || note.ID == 0 {
+		return false, ErrNotFound
+	}
+	return s.likes.Unlike(id, userID)
+}
+
+// Search 在当前用户的笔记中按关键字、标签与时间范围搜索，返回分页结果与总数。
+func (s *noteService) Search(userID uint, opts models.NoteSearchOptions, page, limit int) ([]models.Note, int64, error) {
+	opts.Query = strings.TrimSpace(opts.Query)
+	notes, total, err := s.notes.Search(userID, opts, page, limit)
+	if err == nil {
+		s.markViewerStateList(userID, notes)
+	}
+	return notes, total, err
+}
+
+// RelatedNotes 按标签重合度、新近度与热度为笔记推荐相关的公开笔记；源笔记的可见性规则与 GetNoteByID 一致。
+// 源笔记没有标签或没有共享标签的公开笔记时返回空列表。
+func (s *noteService) RelatedNotes(userID, id uint, limit int) ([]models.RelatedNote, error) {
+	note, err := s.notes.FindByID(id)
+	if err != nil || note == nil || note.ID == 0 {
+		return nil, ErrNotFound
+	}
+	now := time.Now()
+	if !note.IsPubliclyVisible(now) && note.AuthorID != userID {
+		return nil, ErrForbidden
+	}
+	candidates, err := s.notes.Related(id, relatedCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	// 候选列表可能来自缓存，过滤掉缓存之后到期下线的笔记
+	related := make([]models.RelatedNote, 0, len(candidates))
+	var maxPopularity float64
+	for _, n := range candidates {
+		if n.ExpiresAt != nil && !n.ExpiresAt.After(now) {
+			continue
+		}
+		related = append(related, n)
+		maxPopularity = math.Max(maxPopularity, relatedPopularity(&n))
+	}
+	for i := range related {
+		n := &related[i]
+		published := n.UpdatedAt
+		if n.PublishedAt != nil {
+			published = *n.PublishedAt
+		}
+		age := math.Max(now.Sub(published).Hours(), 0)
+		recency := math.Pow(0.5, age/relatedHalfLife.Hours())
+		var popularity float64
+		if maxPopularity > 0 {
+			popularity = relatedPopularity(n) / maxPopularity
+		}
+		n.Score = n.Jaccard*relatedWeightTags + recency*relatedWeightRecency + popularity*relatedWeightPopularity
+	}
+	sort.SliceStable(related, func(i, j int) bool { return related[i].Score > related[j].Score })
+	if len(related) > limit {
+		related = related[:limit]
+	}
+	return related, nil
+}
+
+// relatedPopularity 笔记热度的对数值，点赞比阅读更能代表兴趣，按 relatedLikeWeight 加权
+func relatedPopularity(n *models.RelatedNote) float64 {
+	return math.Log1p(float64(n.Views + n.Likes*relatedLikeWeight))
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"HYH-Blog-Gin/internal/models"
+	"HYH-Blog-Gin/internal/utils"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrShareNotFound = errors.New("share link not found")
+	ErrShareExpired  = errors.New("share link has expired")
+	ErrSharePassword = errors.New("share link password is missing or incorrect")
+	ErrInvalidShare  = errors.New("invalid share: expires_at must be in the future, max_views at least 1 and password at most 72 bytes")
+	ErrTooManyShares = errors.New("too many share links for this note")
+)
+
+// 分享链接限制
+const (
+	MaxNoteShares         = 50 // 单篇笔记最多保留的分享链接数
+	MaxSharePasswordBytes = 72 // bcrypt 只使用密码的前 72 个字节
+	shareTokenBytes       = 16 // Token 的随机字节数（hex 编码后 32 个字符）
+)
+
+// NoteShareInput 创建分享链接的可选限制，零值表示不限制。
+type NoteShareInput struct {
+	ExpiresAt *time.Time
+	Password  string
+	MaxViews  *int
+}
+
+// NoteShareService 提供笔记分享链接相关业务逻辑：
+// - 只有作者可以创建、列出与撤销分享链接；
+// - 持有 Token 的访问者无需登录即可读取笔记，不受笔记公开状态限制，但笔记移入回收站后链接不可用；
+// - 链接到期或访问次数用尽后不可用；设置了密码时需提供正确密码，密码错误不计入访问次数。
+type NoteShareService interface {
+	Create(userID, noteID uint, in NoteShareInput) (*models.NoteShare, error)
+	List(userID, noteID uint) ([]models.NoteShare, error)
+	// Revoke 撤销分享链接，链接不存在时返回 ErrShareNotFound
+	Revoke(userID, noteID, shareID uint) error
+	// Open 凭 Token（与密码）读取笔记并记录一次访问
+	Open(token, password string) (*models.Note, error)
+}
+
+type noteShareService struct {
+	notes  models.NoteRepository
+	shares models.NoteShareRepository
+}
+
+// NewNoteShareService 创建 NoteShareService 实例。
+func NewNoteShareService(notes models.NoteRepository, shares models.NoteShareRepository) NoteShareService {
+	return &noteShareService{notes: notes, shares: shares}
+}
+
+// authorNote 读取笔记并校验作者身份。
+func (s *noteShareService) authorNote(userID, noteID uint) (*models.Note, error) {
+	note, err := s.notes.FindByID(noteID)
+	if err != nil || note == nil || note.ID == 0 {
+		return nil, ErrNotFound
+	}
+	if note.AuthorID != userID {
+		return nil, ErrForbidden
+	}
+	return note, nil
+}
+
+// Create 为笔记生成新的随机 Token；密码以 bcrypt 哈希保存。
+func (s *noteShareService) Create(userID, noteID uint, in NoteShareInput) (*models.NoteShare, error) {
+	if _, err := s.authorNote(userID, noteID); err != nil {
+		return nil, err
+	}
+	if (in.ExpiresAt != nil && !in.ExpiresAt.After(time.Now())) ||
+		(in.MaxViews != nil && *in.MaxViews < 1) ||
+		len(in.Password) > MaxSharePasswordBytes {
+		return nil, ErrInvalidShare
+	}
+	existing, err := s.shares.ListByNote(noteID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxNoteShares {
+		return nil, ErrTooManyShares
+	}
+
+	token, err := utils.RandHex(shareTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	share := &models.NoteShare{NoteID: noteID, Token: token, ExpiresAt: in.ExpiresAt, MaxViews: in.MaxViews}
+	if in.Password != "" {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(in.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		share.PasswordHash = string(hashed)
+	}
+	if err := s.shares.Create(share); err != nil {
+		return nil, err
+	}
+	share.HasPassword = share.PasswordHash != ""
+	return share, nil
+}
+
+// List 列出笔记的全部分享链接。
+func (s *noteShareService) List(userID, noteID uint) ([]models.NoteShare, error) {
+	if _, err := s.authorNote(userID, noteID); err != nil {
+		return nil, err
+	}
+	shares, err := s.shares.ListByNote(noteID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range shares {
+		shares[i].HasPassword = shares[i].PasswordHash != ""
+	}
+	return shares, nil
+}
+
+// Revoke 删除分享链接，之后凭该 Token 的访问返回 ErrShareNotFound。
+func (s *noteShareService) Revoke(userID, noteID, shareID uint) error {
+	if _, err := s.authorNote(userID, noteID); err != nil {
+		return err
+	}
+	deleted, err := s.shares.Delete(noteID, shareID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrShareNotFound
+	}
+	return nil
+}
+
+// Open 依次校验链接是否存在、是否到期、密码是否正确，再原子地记录访问（并发访问时以 Consume 的结果为准）。
+// 返回的笔记隐去作者邮箱。
+func (s *noteShareService) Open(token, password string) (*models.Note, error) {
+	if token == "" {
+		return nil, ErrShareNotFound
+	}
+	share, err := s.shares.FindByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if share == nil {
+		return nil, ErrShareNotFound
+	}
+	if share.IsExpired(time.Now()) {
+		return nil, ErrShareExpired
+	}
+	if share.PasswordHash != "" {
+		if password == "" || bcrypt.CompareHashAndPassword([]byte(share.PasswordHash), []byte(password)) != nil {
+			return nil, ErrSharePassword
+		}
+	}
+	note, err := s.notes.FindByID(share.NoteID)
+	if err != nil || note == nil || note.ID == 0 {
+		return nil, ErrShareNotFound
+	}
+	ok, err := s.shares.Consume(share.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrShareExpired
+	}
+	return withoutAuthorEmail(note), nil
+}
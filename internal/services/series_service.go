@@ -0,0 +1,308 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"HYH-Blog-Gin/internal/models"
+)
+
+var (
+	ErrInvalidSeries = errors.New("series title must be between 1 and 200 characters")
+	ErrInvalidOrder  = errors.New("note_ids must list every note in the series exactly once")
+	ErrNoteInSeries  = errors.New("note already belongs to a series")
+)
+
+// MaxSeriesTitleLength 系列标题的最大字符数
+const MaxSeriesTitleLength = 200
+
+// SeriesInput 创建/更新系列的输入，nil 表示未提供（更新时保持不变）。
+type SeriesInput struct {
+	Title       *string
+	Description *string
+}
+
+// SeriesService 提供笔记系列相关业务逻辑：
+// - 系列归作者所有，只能加入作者自己的笔记，每篇笔记最多属于一个系列；
+// - 作者可以插入、移除成员笔记或整体调整顺序；
+// - 至少包含一篇笔记且全部成员公开未过期的系列对所有人可见。
+type SeriesService interface {
+	Create(userID uint, in SeriesInput) (*models.Series, error)
+	// Get 读取系列及其有序成员；userID 为 0 表示匿名访问
+	Get(userID, id uint) (*models.Series, error)
+	ListMine(userID uint, page, limit int) ([]models.Series, int64, error)
+	ListPublic(authorID uint, page, limit int) ([]models.Series, int64, error)
+	Update(userID, id uint, in SeriesInput) (*models.Series, error)
+	Delete(userID, id uint) error
+	// InsertNote 将笔记插入到 position（从 1 开始）处，position 为 nil 或超出范围时追加到末尾
+	InsertNote(userID, id, noteID uint, position *int) (*models.Series, error)
+	RemoveNote(userID, id, noteID uint) (*models.Series, error)
+	// Reorder 按 noteIDs 的顺序重排成员笔记，noteIDs 必须恰好包含当前全部成员
+	Reorder(userID, id uint, noteIDs []uint) (*models.Series, error)
+}
+
+type seriesService struct {
+	notes  models.NoteRepository
+	series models.SeriesRepository
+}
+
+// NewSeriesService 创建 SeriesService 实例。
+func NewSeriesService(notes models.NoteRepository, series models.SeriesRepository) SeriesService {
+	return &seriesService{notes: notes, series: series}
+}
+
+// applySeriesInput 规范化并校验输入，写入系列。
+func applySeriesInput(s *models.Series, in SeriesInput) error {
+	if in.Title != nil {
+		title := strings.TrimSpace(*in.Title)
+		if title == "" || utf8.RuneCountInString(title) > MaxSeriesTitleLength {
+			return ErrInvalidSeries
+		}
+		s.Title = title
+	}
+	if in.Description != nil {
+		s.Description = strings.TrimSpace(*in.Description)
+	}
+	return nil
+}
+
+// owned 读取系列并校验归属。
+func (s *seriesService) owned(userID, id uint) (*models.Series, error) {
+	series, err := s.series.FindByID(id)
+	if err != nil || series == nil || series.ID == 0 {
+		return nil, ErrNotFound
+	}
+	if series.AuthorID != userID {
+		return nil, ErrForbidden
+	}
+	return series, nil
+}
+
+// withItems 填充系列的有序成员。
+func (s *seriesService) withItems(series *models.Series) (*models.Series, error) {
+	items, err := s.series.Items(series.ID)
+	if err != nil {
+		return nil, err
+	}
+	series.Notes = items
+	series.NoteCount = int64(len(items))
+	return series, nil
+}
+
+// itemIDs 返回成员笔记 ID 列表。
+func itemIDs(items []models.SeriesItem) []uint {
+	ids := make([]uint, 0, len(items))
+	for _, it := range items {
+		ids = append(ids, it.NoteID)
+	}
+	return ids
+}
+
+// Create 创建系列，标题必填。
+func (s *seriesService) Create(userID uint, in SeriesInput) (*models.Series, error) {
+	if in.Title == nil {
+		return nil, ErrInvalidSeries
+	}
+	series := &models.Series{AuthorID: userID}
+	if err := applySeriesInput(series, in); err != nil {
+		return nil, err
+	}
+	if err := s.series.Create(series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// Get 读取系列。非作者只能读取公开系列（成员笔记全部可列出），否则返回 forbidden。
+func (s *seriesService) Get(userID, id uint) (*models.Series, error) {
+	series, err := s.series.FindByID(id)
+	if err != nil || series == nil || series.ID == 0 {
+		return nil, ErrNotFound
+	}
+	if _, err := s.withItems(series); err != nil {
+		return nil, err
+	}
+	if series.AuthorID == userID {
+		return series, nil
+	}
+	if len(series.Notes) == 0 {
+		return nil, ErrForbidden
+	}
+	now := time.Now()
+	for i := range series.Notes {
+		if !series.Notes[i].IsListed(now) {
+			return nil, ErrForbidden
+		}
+	}
+	return series, nil
+}
+
+// ListMine 分页列出当前用户的全部系列。
+func (s *seriesService) ListMine(userID uint, page, limit int) ([]models.Series, int64, error) {
+	return s.series.FindByAuthor(userID, page, limit)
+}
+
+// ListPublic 分页列出公开系列。
+func (s *seriesService) ListPublic(authorID uint, page, limit int) ([]models.Series, int64, error) {
+	return s.series.FindPublic(authorID, page, limit)
+}
+
+// Update 更新系列的标题与简介，只有作者可更新。
+func (s *seriesService) Update(userID, id uint, in SeriesInput) (*models.Series, error) {
+	series, err := s.owned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applySeriesInput(series, in); err != nil {
+		return nil, err
+	}
+	if err := s.series.Update(series); err != nil {
+		return nil, err
+	}
+	return s.withItems(series)
+}
+
+// Delete 删除系列，成员笔记保留。
+func (s *seriesService) Delete(userID, id uint) error {
+	if _, err := s.owned(userID, id); err != nil {
+		return err
+	}
+	return s.series.Delete(id)
+}
+
+// InsertNote 将作者自己的笔记插入系列；笔记已在本系列中时移动到新位置。
+func (s *seriesService) InsertNote(userID, id, noteID uint, position *int) (*models.Series, error) {
+	series, err := s.owned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	note, err := s.notes.FindByID(noteID)
+	if err != nil || note == nil || note.ID == 0 {
+		return nil, ErrNotFound
+	}
+	if note.AuthorID != userID {
+		return nil, ErrForbidden
+	}
+	current, err := s.series.FindByNote(noteID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.ID != id {
+		return nil, ErrNoteInSeries
+	}
+
+	items, err := s.series.Items(id)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(items)+1)
+	for _, nid := range itemIDs(items) {
+		if nid != noteID {
+			ids = append(ids, nid)
+		}
+	}
+	at := len(ids)
+	if position != nil && *position >= 1 && *position <= len(ids) {
+		at = *position - 1
+	}
+	ids = append(ids[:at], append([]uint{noteID}, ids[at:]...)...)
+	if err := s.series.SetNotes(id, ids); err != nil {
+		return nil, err
+	}
+	return s.withItems(series)
+}
+
+// RemoveNote 从系列中移除笔记，笔记不在系列中时返回 not found。
+func (s *seriesService) RemoveNote(userID, id, noteID uint) (*models.Series, error) {
+	series, err := s.owned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.series.Items(id)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(items))
+	for _, nid := range itemIDs(items) {
+		if nid != noteID {
+			ids = append(ids, nid)
+		}
+	}
+	if len(ids) == len(items) {
+		return nil, ErrNotFound
+	}
+	if err := s.series.SetNotes(id, ids); err != nil {
+		return nil, err
+	}
+	return s.withItems(series)
+}
+
+// Reorder 重排成员笔记。
+func (s *seriesService) Reorder(userID, id uint, noteIDs []uint) (*models.Series, error) {
+	series, err := s.owned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.series.Items(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(noteIDs) != len(items) {
+		return nil, ErrInvalidOrder
+	}
+	members := make(map[uint]bool, len(items))
+	for _, nid := range itemIDs(items) {
+		members[nid] = true
+	}
+	for _, nid := range noteIDs {
+		if !members[nid] {
+			return nil, ErrInvalidOrder
+		}
+		// 每个成员只能出现一次
+		delete(members, nid)
+	}
+	if err := s.series.SetNotes(id, noteIDs); err != nil {
+		return nil, err
+	}
+	return s.withItems(series)
+}
+
+// seriesContext 计算笔记在所属系列中的位置，非作者只统计可列出的成员笔记；笔记不属于任何系列或对非作者不可列出时返回 nil。
+func seriesContext(repo models.SeriesRepository, userID uint, note *models.Note) *models.NoteSeriesContext {
+	series, err := repo.FindByNote(note.ID)
+	if err != nil || series == nil {
+		return nil
+	}
+	items, err := repo.Items(series.ID)
+	if err != nil {
+		return nil
+	}
+	if series.AuthorID != userID {
+		now := time.Now()
+		visible := items[:0]
+		for _, it := range items {
+			if it.IsListed(now) {
+				visible = append(visible, it)
+			}
+		}
+		items = visible
+	}
+	for i := range items {
+		if items[i].NoteID != note.ID {
+			continue
+		}
+		ctx := &models.NoteSeriesContext{ID: series.ID, Title: series.Title, Position: i + 1, Total: len(items)}
+		if i > 0 {
+			prev := items[i-1]
+			ctx.Prev = &prev
+		}
+		if i < len(items)-1 {
+			next := items[i+1]
+			ctx.Next = &next
+		}
+		return ctx
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SiteSettings 生成站点绝对地址所需的配置，与 config.SiteConfig 对应。
+// NotePath、UserPath 与 TagPath 为笔记、作者与标签页面的路径模板：
+// 笔记支持 {id}、{username} 与 {slug}，作者支持 {id} 与 {username}，标签支持 {tag}。
+type SiteSettings struct {
+	URL         string
+	Title       string
+	Description string
+	NotePath    string
+	UserPath    string
+	TagPath     string
+}
+
+// noteURL 生成笔记页面的绝对地址；笔记没有 slug 时以 ID 代替。
+func (s SiteSettings) noteURL(id uint, username, slug string) string {
+	sid := strconv.FormatUint(uint64(id), 10)
+	if slug == "" {
+		slug = sid
+	}
+	return s.URL + strings.NewReplacer(
+		"{id}", sid,
+		"{username}", escapeURLValue(username),
+		"{slug}", escapeURLValue(slug),
+	).Replace(s.NotePath)
+}
+
+// userURL 生成作者页面的绝对地址。
+func (s SiteSettings) userURL(id uint, username string) string {
+	return s.URL + strings.NewReplacer(
+		"{id}", strconv.FormatUint(uint64(id), 10),
+		"{username}", escapeURLValue(username),
+	).Replace(s.UserPath)
+}
+
+// tagURL 生成标签页面的绝对地址。
+func (s SiteSettings) tagURL(tag string) string {
+	return s.URL + strings.ReplaceAll(s.TagPath, "{tag}", escapeURLValue(tag))
+}
+
+// escapeURLValue 转义路径模板中的占位符取值，结果既可作为路径段也可作为查询参数值（空格编码为 %20）。
+func escapeURLValue(v string) string {
+	return strings.ReplaceAll(url.QueryEscape(v), "+", "%20")
+}
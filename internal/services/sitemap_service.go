@@ -0,0 +1,167 @@
+package services
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"HYH-Blog-Gin/internal/models"
+)
+
+// 站点地图限制
+const (
+	SitemapMaxURLs   = 50000 // 单个站点地图文件最多包含的地址数（sitemaps.org 协议上限）
+	sitemapBatchSize = 1000  // 每批从数据库读取的笔记数量
+)
+
+// SitemapFiles 生成好的站点地图。地址总数不超过 SitemapMaxURLs 时 Root 即为完整的 urlset，Pages 为空；
+// 否则 Root 为 sitemapindex，依次指向 Pages 对应的 /sitemap/1.xml、/sitemap/2.xml…
+type SitemapFiles struct {
+	Root  string   `json:"root"`
+	Pages []string `json:"pages"`
+}
+
+// RobotsSettings robots.txt 的生成配置：Text 非空时原样返回，否则按 Disallow 生成并附上站点地图地址。
+type RobotsSettings struct {
+	Disallow []string
+	Text     string
+}
+
+// SitemapService 生成站点地图与 robots.txt。
+type SitemapService interface {
+	// Generate 生成包含首页、作者页、标签页与全部公开笔记的站点地图
+	Generate() (*SitemapFiles, error)
+	// Robots 返回 robots.txt 的内容
+	Robots() string
+}
+
+type sitemapService struct {
+	repo   models.SitemapRepository
+	site   SiteSettings
+	robots RobotsSettings
+}
+
+// NewSitemapService 创建 SitemapService 实例。
+func NewSitemapService(repo models.SitemapRepository, site SiteSettings, robots RobotsSettings) SitemapService {
+	return &sitemapService{repo: repo, site: site, robots: robots}
+}
+
+// sitemapPage 正在写入的一个站点地图文件
+type sitemapPage struct {
+	buf     bytes.Buffer
+	count   int
+	lastmod time.Time
+}
+
+// sitemapWriter 依次写入地址，每满 SitemapMaxURLs 条开始新的文件。
+type sitemapWriter struct {
+	pages []*sitemapPage
+}
+
+func (w *sitemapWriter) add(loc string, lastmod time.Time) {
+	if len(w.pages) == 0 || w.pages[len(w.pages)-1].count >= SitemapMaxURLs {
+		p := &sitemapPage{}
+		p.buf.WriteString(xml.Header)
+		p.buf.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` + "\n")
+		w.pages = append(w.pages, p)
+	}
+	p := w.pages[len(w.pages)-1]
+	p.count++
+	p.buf.WriteString("  <url><loc>")
+	_ = xml.EscapeText(&p.buf, []byte(loc))
+	p.buf.WriteString("</loc>")
+	if !lastmod.IsZero() {
+		p.buf.WriteString("<lastmod>" + sitemapDate(lastmod) + "</lastmod>")
+		if lastmod.After(p.lastmod) {
+			p.lastmod = lastmod
+		}
+	}
+	p.buf.WriteString("</url>\n")
+}
+
+// sitemapDate 站点地图使用 W3C Datetime 格式的日期
+func sitemapDate(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Generate 先写入首页、作者页与标签页，再按 ID 分批遍历公开笔记；
+// lastmod 取自笔记的 updated_at，作者页与标签页取其下公开笔记中最近的更新时间。
+func (s *sitemapService) Generate() (*SitemapFiles, error) {
+	authors, err := s.repo.PublicAuthors()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.repo.PublicTags()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &sitemapWriter{}
+	var home time.Time
+	for _, a := range authors {
+		if a.UpdatedAt.After(home) {
+			home = a.UpdatedAt
+		}
+	}
+	w.add(s.site.URL+"/", home)
+	for _, a := range authors {
+		w.add(s.site.userURL(a.ID, a.Name), a.UpdatedAt)
+	}
+	for _, t := range tags {
+		w.add(s.site.tagURL(t.Name), t.UpdatedAt)
+	}
+	err = s.repo.EachPublicNote(sitemapBatchSize, func(notes []models.SitemapNote) error {
+		for _, n := range notes {
+			w.add(s.site.noteURL(n.ID, n.Username, n.Slug), n.UpdatedAt)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range w.pages {
+		p.buf.WriteString("</urlset>\n")
+	}
+	if len(w.pages) == 1 {
+		return &SitemapFiles{Root: w.pages[0].buf.String()}, nil
+	}
+
+	files := &SitemapFiles{Pages: make([]string, 0, len(w.pages))}
+	var index bytes.Buffer
+	index.WriteString(xml.Header)
+	index.WriteString(`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` + "\n")
+	for i, p := range w.pages {
+		files.Pages = append(files.Pages, p.buf.String())
+		index.WriteString("  <sitemap><loc>")
+		_ = xml.EscapeText(&index, []byte(s.site.URL+"/sitemap/"+strconv.Itoa(i+1)+".xml"))
+		index.WriteString("</loc>")
+		if !p.lastmod.IsZero() {
+			index.WriteString("<lastmod>" + sitemapDate(p.lastmod) + "</lastmod>")
+		}
+		index.WriteString("</sitemap>\n")
+	}
+	index.WriteString("</sitemapindex>\n")
+	files.Root = index.String()
+	return files, nil
+}
+
+// Robots 未配置 robots.txt 文件时，允许所有爬虫抓取 Disallow 以外的路径并声明站点地图地址。
+func (s *sitemapService) Robots() string {
+	if s.robots.Text != "" {
+		return s.robots.Text
+	}
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	if len(s.robots.Disallow) == 0 {
+		b.WriteString("Disallow:\n")
+	}
+	for _, p := range s.robots.Disallow {
+		fmt.Fprintf(&b, "Disallow: %s\n", p)
+	}
+	fmt.Fprintf(&b, "\nSitemap: %s/sitemap.xml\n", s.site.URL)
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+
+	"HYH-Blog-Gin/internal/models"
+	"HYH-Blog-Gin/internal/trending"
+)
+
+// MaxTrendingNotes 热度排行单次最多返回的笔记数
+const MaxTrendingNotes = 50
+
+// TrendingService 记录阅读与点赞事件并提供带时间衰减的热度排行。
+type TrendingService interface {
+	// RecordView 记录一次阅读
+	RecordView(noteID uint)
+	// RecordLike 记录点赞数变化，delta 为 1（点赞）或 -1（取消点赞）
+	RecordLike(noteID uint, delta int64)
+	// Trending 返回窗口内热度最高的公开笔记，按热度倒序
+	Trending(window trending.Window, limit int) ([]models.Note, error)
+}
+
+type trendingService struct {
+	notes   models.NoteRepository
+	tracker trending.Tracker
+}
+
+// NewTrendingService 创建 TrendingService 实例，tracker 为 nil 时排行始终为空。
+func NewTrendingService(notes models.NoteRepository, tracker trending.Tracker) TrendingService {
+	if tracker == nil {
+		tracker = trending.NewNoOpTracker()
+	}
+	return &trendingService{notes: notes, tracker: tracker}
+}
+
+// RecordView 热度只用于排序，写入失败时忽略
+func (s *trendingService) RecordView(noteID uint) {
+	_ = s.tracker.Add(context.Background(), noteID, trending.ViewPoints)
+}
+
+func (s *trendingService) RecordLike(noteID uint, delta int64) {
+	_ = s.tracker.Add(context.Background(), noteID, float64(delta*trending.LikePoints))
+}
+
+// Trending 多取一部分排行成员后过滤掉已删除、下线、非公开（例如作者阅读自己的私有笔记）或不公开列出的笔记，
+// 并把这些笔记从排行中移除，使之后的查询不再受其影响。
+func (s *trendingService) Trending(window trending.Window, limit int) ([]models.Note, error) {
+	ctx := context.Background()
+	ids, err := s.tracker.Top(ctx, window, limit*2)
+	if err != nil {
+		return nil, err
+	}
+	found, err := s.notes.FindPublicByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Note, len(found))
+	for _, n := range found {
+		byID[n.ID] = n
+	}
+	notes := make([]models.Note, 0, limit)
+	var stale []uint
+	for _, id := range ids {
+		n, ok := byID[id]
+		if !ok {
+			stale = append(stale, id)
+			continue
+		}
+		if len(notes) < limit {
+			notes = append(notes, n)
+		}
+	}
+	if len(stale) > 0 {
+		_ = s.tracker.Remove(ctx, stale...)
+	}
+	return notes, nil
+}
@@ -0,0 +1,252 @@
+package trending
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis 键
+const (
+	keyPrefix = "trending:"
+	// epochKey 当前分数的基准时间（Unix 秒，浮点数）
+	epochKey = keyPrefix + "epoch"
+	// rebuildEpochKey 重建进行中时存在，值为重建的基准时间；期间 Add 同时把增量记入各窗口的 :delta 键
+	rebuildEpochKey = keyPrefix + "rebuild:epoch"
+	// rebuildLockKey 重建锁，多个实例同时启动时只有一个实例重建
+	rebuildLockKey = keyPrefix + "rebuild:lock"
+)
+
+// rebuildLockTTL 重建锁的有效期：成功后不主动释放，滚动部署期间后续启动的实例不再重复重建；
+// 同时也是 rebuildEpochKey 的有效期，重建中途退出时增量记录随之停止
+const rebuildLockTTL = 10 * time.Minute
+
+// windowKey 窗口排行的有序集合键
+func windowKey(w Window) string { return keyPrefix + string(w) }
+
+// seedKey 重建时写入历史热度的临时键
+func seedKey(w Window) string { return windowKey(w) + ":seed" }
+
+// deltaKey 重建期间记录增量的临时键，分数以重建的基准时间计算
+func deltaKey(w Window) string { return windowKey(w) + ":delta" }
+
+// addScript 读取 epoch（不存在时以当前时刻初始化）后为每个窗口按各自的半衰期累加分数；
+// 重建进行中时再以重建的基准时间把同一增量记入 :delta 键，重建完成时合并，不会丢失。
+// KEYS: epoch, 重建 epoch, 各窗口键, 各窗口 :delta 键；ARGV: 成员, points, 当前时刻, 各窗口半衰期（秒）
+var addScript = redis.NewScript(`
+local now = tonumber(ARGV[3])
+local epoch = tonumber(redis.call('GET', KEYS[1]))
+if not epoch then
+	epoch = now
+	redis.call('SET', KEYS[1], ARGV[3])
+end
+local rebuildEpoch = tonumber(redis.call('GET', KEYS[2]))
+local n = (#KEYS - 2) / 2
+for i = 1, n do
+	local halfLife = tonumber(ARGV[i + 3])
+	redis.call('ZINCRBY', KEYS[i + 2], tonumber(ARGV[2]) * math.pow(2, (now - epoch) / halfLife), ARGV[1])
+	if rebuildEpoch then
+		redis.call('ZINCRBY', KEYS[i + 2 + n], tonumber(ARGV[2]) * math.pow(2, (now - rebuildEpoch) / halfLife), ARGV[1])
+	end
+end
+return 1
+`)
+
+// mergeScript 以 :seed 与 :delta 键之和替换各窗口的排行，移除低于下限的成员，把 epoch 设为重建的基准时间并结束增量记录。
+// KEYS: epoch, 重建 epoch, 各窗口键, 各窗口 :seed 键, 各窗口 :delta 键；ARGV: 重建的基准时间, 分数下限
+var mergeScript = redis.NewScript(`
+local n = (#KEYS - 2) / 3
+for i = 1, n do
+	local w, seed, delta = KEYS[i + 2], KEYS[i + 2 + n], KEYS[i + 2 + 2 * n]
+	redis.call('ZUNIONSTORE', w, 2, seed, delta)
+	redis.call('ZREMRANGEBYSCORE', w, '-inf', '(' .. ARGV[2])
+	redis.call('DEL', seed, delta)
+end
+redis.call('SET', KEYS[1], ARGV[1])
+redis.call('DEL', KEYS[2])
+return 1
+`)
+
+// rebaseScript 把各窗口的分数乘以 2^(-(now-epoch)/halfLife)，移除低于下限的成员，并把 epoch 设为当前时刻。
+// KEYS: epoch, 各窗口键；ARGV: 当前时刻, 分数下限, 各窗口半衰期（秒）
+var rebaseScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local epoch = tonumber(redis.call('GET', KEYS[1]))
+redis.call('SET', KEYS[1], ARGV[1])
+if not epoch then
+	return 0
+end
+for i = 2, #KEYS do
+	local factor = math.pow(2, -(now - epoch) / tonumber(ARGV[i + 1]))
+	redis.call('ZUNIONSTORE', KEYS[i], 1, KEYS[i], 'WEIGHTS', factor)
+	redis.call('ZREMRANGEBYSCORE', KEYS[i], '-inf', '(' .. ARGV[2])
+end
+return 1
+`)
+
+// redisTracker 基于 Redis 有序集合的 Tracker 实现，写入均为原子脚本，可在多个实例间共享。
+type redisTracker struct {
+	rdb *redis.Client
+}
+
+// NewRedisTracker 创建基于 Redis 的热度排行
+func NewRedisTracker(rdb *redis.Client) Tracker {
+	return &redisTracker{rdb: rdb}
+}
+
+// scriptKeys 返回 epoch 键与全部窗口键
+func scriptKeys() []string {
+	keys := []string{epochKey}
+	for _, w := range Windows {
+		keys = append(keys, windowKey(w))
+	}
+	return keys
+}
+
+// rebuildKeys 返回 epoch 键、重建 epoch 键，以及按 kinds 依次列出的全部窗口键
+func rebuildKeys(kinds ...func(Window) string) []string {
+	keys := []string{epochKey, rebuildEpochKey}
+	for _, kind := range kinds {
+		for _, w := range Windows {
+			keys = append(keys, kind(w))
+		}
+	}
+	return keys
+}
+
+// unixSeconds 以浮点秒表示时间，作为脚本参数
+func unixSeconds(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', 3, 64)
+}
+
+func (t *redisTracker) Add(ctx context.Context, noteID uint, points float64) error {
+	args := []interface{}{noteID, points, unixSeconds(time.Now())}
+	for _, w := range Windows {
+		args = append(args, w.HalfLife().Seconds())
+	}
+	if err := addScript.Run(ctx, t.rdb, rebuildKeys(windowKey, deltaKey), args...).Err(); err != nil {
+		return fmt.Errorf("更新热度失败: %w", err)
+	}
+	return nil
+}
+
+func (t *redisTracker) Top(ctx context.Context, w Window, limit int) ([]uint, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	members, err := t.rdb.ZRevRange(ctx, windowKey(w), 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("读取热度排行失败: %w", err)
+	}
+	ids := make([]uint, 0, len(members))
+	for _, m := range members {
+		if id, err := strconv.ParseUint(m, 10, 64); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids, nil
+}
+
+func (t *redisTracker) Remove(ctx context.Context, noteIDs ...uint) error {
+	if len(noteIDs) == 0 {
+		return nil
+	}
+	members := make([]interface{}, 0, len(noteIDs))
+	for _, id := range noteIDs {
+		members = append(members, id)
+	}
+	_, err := t.rdb.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		for _, w := range Windows {
+			p.ZRem(ctx, windowKey(w), members...)
+			p.ZRem(ctx, seedKey(w), members...)
+			p.ZRem(ctx, deltaKey(w), members...)
+		}
+		return nil
+	})
+	return err
+}
+
+// Rebuild 先取得重建锁并开始记录增量，再加载历史热度写入 :seed 键，最后由脚本原子地合并 :seed 与 :delta 并替换各窗口；
+// 重建期间排行始终可读，Add 的增量也不会丢失。锁被其他实例持有时返回 ErrRebuildSkipped。
+func (t *redisTracker) Rebuild(ctx context.Context, load func(context.Context) ([]Seed, error)) error {
+	ok, err := t.rdb.SetNX(ctx, rebuildLockKey, 1, rebuildLockTTL).Result()
+	if err != nil {
+		return fmt.Errorf("重建热度排行失败: %w", err)
+	}
+	if !ok {
+		return ErrRebuildSkipped
+	}
+	if err := t.rebuild(ctx, load); err != nil {
+		// 失败时停止记录增量并释放锁，允许稍后重试
+		keys := rebuildKeys(seedKey, deltaKey)[1:]
+		_ = t.rdb.Del(context.WithoutCancel(ctx), append(keys, rebuildLockKey)...).Err()
+		return err
+	}
+	return nil
+}
+
+// rebuild 执行一次重建，调用方持有重建锁。
+func (t *redisTracker) rebuild(ctx context.Context, load func(context.Context) ([]Seed, error)) error {
+	now := time.Now()
+	_, err := t.rdb.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Del(ctx, rebuildKeys(seedKey, deltaKey)[2:]...)
+		p.Set(ctx, rebuildEpochKey, unixSeconds(now), rebuildLockTTL)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("重建热度排行失败: %w", err)
+	}
+
+	seeds, err := load(ctx)
+	if err != nil {
+		return err
+	}
+	for _, w := range Windows {
+		halfLife := w.HalfLife().Seconds()
+		batch := make([]redis.Z, 0, 500)
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			err := t.rdb.ZAdd(ctx, seedKey(w), batch...).Err()
+			batch = batch[:0]
+			return err
+		}
+		for _, s := range seeds {
+			score := s.Points * math.Pow(2, s.At.Sub(now).Seconds()/halfLife)
+			if score < minScore {
+				continue
+			}
+			batch = append(batch, redis.Z{Score: score, Member: s.NoteID})
+			if len(batch) == cap(batch) {
+				if err := flush(); err != nil {
+					return fmt.Errorf("重建热度排行失败: %w", err)
+				}
+			}
+		}
+		if err := flush(); err != nil {
+			return fmt.Errorf("重建热度排行失败: %w", err)
+		}
+	}
+
+	keys := rebuildKeys(windowKey, seedKey, deltaKey)
+	if err := mergeScript.Run(ctx, t.rdb, keys, unixSeconds(now), minScore).Err(); err != nil {
+		return fmt.Errorf("重建热度排行失败: %w", err)
+	}
+	return nil
+}
+
+func (t *redisTracker) Rebase(ctx context.Context) error {
+	args := []interface{}{unixSeconds(time.Now()), minScore}
+	for _, w := range Windows {
+		args = append(args, w.HalfLife().Seconds())
+	}
+	if err := rebaseScript.Run(ctx, t.rdb, scriptKeys(), args...).Err(); err != nil {
+		return fmt.Errorf("推进热度基准时间失败: %w", err)
+	}
+	return nil
+}
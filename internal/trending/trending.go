@@ -0,0 +1,108 @@
+// Package trending 维护带时间衰减的笔记热度排行。
+//
+// 热度采用前向指数衰减：每次阅读或点赞在发生时刻 t 计入 points × 2^((t-epoch)/halfLife)，
+// 排行按累计值排序，等价于把每个事件按距今的时间以 halfLife 为半衰期衰减后求和，因此已记录的分数无需随时间重算。
+// epoch 由定期的 Rebase 推进，避免指数过大溢出；重建时没有逐次事件的时间，全部历史热度按笔记的发布时间计入，
+// 与 Hacker News 等按发布时间衰减的排名方式一致。
+package trending
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrRebuildSkipped 其他实例正在重建或刚刚完成重建，本次重建被跳过
+var ErrRebuildSkipped = errors.New("trending rebuild skipped: another instance holds the rebuild lock")
+
+// Window 热度排行的时间窗口
+type Window string
+
+// 热度窗口
+const (
+	WindowDay   Window = "day"
+	WindowWeek  Window = "week"
+	WindowMonth Window = "month"
+)
+
+// Windows 全部热度窗口，每个窗口各维护一个排行
+var Windows = []Window{WindowDay, WindowWeek, WindowMonth}
+
+// 事件分值：点赞比阅读更能代表读者兴趣
+const (
+	ViewPoints = 1
+	LikePoints = 5
+)
+
+// minScore 低于该值（相当于当前时刻不到 0.01 次阅读）的成员在重建与 Rebase 时移除，使排行规模保持有界
+const minScore = 0.01
+
+// ParseWindow 解析窗口名称，不合法时返回 false。
+func ParseWindow(s string) (Window, bool) {
+	for _, w := range Windows {
+		if string(w) == s {
+			return w, true
+		}
+	}
+	return "", false
+}
+
+// HalfLife 窗口对应的半衰期：窗口长度的 1/3，一个窗口之前的热度只保留 1/8。
+func (w Window) HalfLife() time.Duration {
+	switch w {
+	case WindowWeek:
+		return 7 * 24 * time.Hour / 3
+	case WindowMonth:
+		return 30 * 24 * time.Hour / 3
+	default:
+		return 24 * time.Hour / 3
+	}
+}
+
+// Points 由阅读量与点赞数计算热度分值
+func Points(views, likes int64) float64 {
+	return float64(views*ViewPoints + likes*LikePoints)
+}
+
+// Seed 重建排行时一篇笔记的历史热度，Points 在 At（发布时间）一次性计入。
+type Seed struct {
+	NoteID uint
+	Points float64
+	At     time.Time
+}
+
+// Tracker 热度排行存储。
+type Tracker interface {
+	// Add 在当前时刻为笔记计入 points（可为负数，例如取消点赞），同时更新全部窗口
+	Add(ctx context.Context, noteID uint, points float64) error
+	// Top 返回窗口内热度最高的 limit 篇笔记 ID，按热度倒序
+	Top(ctx context.Context, w Window, limit int) ([]uint, error)
+	// Remove 从全部窗口移除笔记（已删除或不再公开）
+	Remove(ctx context.Context, noteIDs ...uint) error
+	// Rebuild 以 load 返回的 seeds 替换全部窗口的排行，并把 epoch 重置为重建开始的时刻；
+	// 重建期间的 Add 在完成时一并计入。其他实例正在（或刚刚完成）重建时返回 ErrRebuildSkipped
+	Rebuild(ctx context.Context, load func(context.Context) ([]Seed, error)) error
+	// Rebase 把 epoch 推进到当前时刻并按比例缩小已有分数，同时移除可以忽略的成员
+	Rebase(ctx context.Context) error
+}
+
+// NoOpTracker 未配置 Redis 时使用的空实现，排行始终为空。
+type NoOpTracker struct{}
+
+// NewNoOpTracker 创建空实现
+func NewNoOpTracker() Tracker { return &NoOpTracker{} }
+
+// Add 无操作实现
+func (*NoOpTracker) Add(context.Context, uint, float64) error { return nil }
+
+// Top 无操作实现
+func (*NoOpTracker) Top(context.Context, Window, int) ([]uint, error) { return nil, nil }
+
+// Remove 无操作实现
+func (*NoOpTracker) Remove(context.Context, ...uint) error { return nil }
+
+// Rebuild 无操作实现
+func (*NoOpTracker) Rebuild(context.Context, func(context.Context) ([]Seed, error)) error { return nil }
+
+// Rebase 无操作实现
+func (*NoOpTracker) Rebase(context.Context) error { return nil }
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// cjkRanges 视为 CJK 的码点区间：日文假名、CJK 统一表意文字（含扩展 A）、兼容表意文字、韩文音节。
+var cjkRanges = [][2]rune{
+	{0x3040, 0x30FF},
+	{0x3400, 0x4DBF},
+	{0x4E00, 0x9FFF},
+	{0xF900, 0xFAFF},
+	{0xAC00, 0xD7AF},
+}
+
+// IsCJK 判断字符是否为 CJK 字符（中日韩表意文字、假名或韩文音节）。
+func IsCJK(r rune) bool {
+	for _, rg := range cjkRanges {
+		if r >= rg[0] && r <= rg[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// CJKCharClassPG 返回与 IsCJK 等价的 PostgreSQL 正则字符类（带捕获组），例如 ([぀-ヿ...])。
+func CJKCharClassPG() string {
+	var b strings.Builder
+	b.WriteString("([")
+	for _, rg := range cjkRanges {
+		fmt.Fprintf(&b, `\u%04x-\u%04x`, rg[0], rg[1])
+	}
+	b.WriteString("])")
+	return b.String()
+}
+
+// SegmentCJKQuery 将搜索词中的 CJK 文本按字切分，并把连续的 CJK 字符包成短语（"中 文"），
+// 以便 websearch_to_tsquery 生成相邻匹配（<->）而不是任意位置的 AND。
+// 已位于用户引号短语内的 CJK 字符只切分、不再额外加引号；排除运算符（-）保持紧贴生成的短语（-排除 → -"排 除"），
+// 以 - 开头的词中间出现的 CJK 短语同样被排除（-go排除 → -go -"排 除"）。
+func SegmentCJKQuery(q string) string {
+	var b strings.Builder
+	inQuote := false // 用户自己输入的引号
+	inRun := false   // 由本函数开启的 CJK 短语
+	negated := false // 当前词以 - 开头
+	prev := ' '      // 输入中的上一个字符
+	for _, r := range q {
+		if IsCJK(r) {
+			if !inRun && !inQuote {
+				switch {
+				case prev == '-' && negated:
+					b.WriteByte('"')
+				case negated:
+					b.WriteString(` -"`)
+				default:
+					b.WriteString(` "`)
+				}
+				inRun = true
+			} else {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			prev = r
+			continue
+		}
+		if inRun {
+			b.WriteString(`" `)
+			inRun = false
+		}
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case unicode.IsSpace(r):
+			negated = false
+		case r == '-' && unicode.IsSpace(prev) && !inQuote:
+			negated = true
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	if inRun {
+		b.WriteByte('"')
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// JoinCJK 移除按字切分时插入在 CJK 字符之间的空格，忽略夹在其间的 markers（如高亮标记）。
+func JoinCJK(s string, markers ...string) string {
+	runes := []rune(s)
+	isMarkerAt := func(i int) (int, bool) {
+		for _, m := range markers {
+			mr := []rune(m)
+			if len(mr) > 0 && i+len(mr) <= len(runes) && string(runes[i:i+len(mr)]) == m {
+				return len(mr), true
+			}
+		}
+		return 0, false
+	}
+	// prevCJK 记录最近一个非标记、非空白字符是否为 CJK
+	var b strings.Builder
+	prevCJK := false
+	for i := 0; i < len(runes); {
+		if n, ok := isMarkerAt(i); ok {
+			b.WriteString(string(runes[i : i+n]))
+			i += n
+			continue
+		}
+		r := runes[i]
+		if unicode.IsSpace(r) && prevCJK {
+			// 向后跳过空白与标记，查看下一个实际字符
+			j := i
+			for j < len(runes) {
+				if n, ok := isMarkerAt(j); ok {
+					j += n
+					continue
+				}
+				if !unicode.IsSpace(runes[j]) {
+					break
+				}
+				j++
+			}
+			if j < len(runes) && IsCJK(runes[j]) {
+				i++
+				continue
+			}
+		}
+		b.WriteRune(r)
+		prevCJK = IsCJK(r)
+		i++
+	}
+	return b.String()
+}
@@ -0,0 +1,29 @@
+package utils
+
+import "testing"
+
+func TestSegmentCJKQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"latin only", "go gin", "go gin"},
+		{"cjk run", "中文", `"中 文"`},
+		{"mixed", "学习 go", `"学 习"  go`},
+		{"negated cjk", "-排除 go", `-"排 除"  go`},
+		{"negated after term", "go -排除", `go -"排 除"`},
+		{"negated mixed token", "-go排除", `-go -"排 除"`},
+		{"hyphen inside word", "e-排除", `e- "排 除"`},
+		{"quoted phrase", `"中文 go"`, `" 中 文 go"`},
+		{"negated quoted phrase", `-"中文"`, `-" 中 文"`},
+		{"or operator", "中文 or 日本", `"中 文"  or  "日 本"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SegmentCJKQuery(tt.in); got != tt.want {
+				t.Errorf("SegmentCJKQuery(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package utils
+
+import "strings"
+
+// DiffOp 行级差异的操作类型
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+// DiffLine 行级差异中的一行。OldLine/NewLine 为 1 起始的行号，不适用时为 0。
+type DiffLine struct {
+	Op      DiffOp `json:"op"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+	Text    string `json:"text"`
+}
+
+// maxDiffEditDistance 限制 Myers 算法的搜索深度，超出时将剩余部分视为整体替换，避免超大文本耗尽内存。
+const maxDiffEditDistance = 2000
+
+// DiffLines 使用 Myers 算法计算 a 到 b 的行级差异（统一换行符为 \n）。
+func DiffLines(a, b string) []DiffLine {
+	al, bl := splitLines(a), splitLines(b)
+
+	// 先剥离公共前缀与后缀，缩小 Myers 的搜索规模
+	prefix := 0
+	for prefix < len(al) && prefix < len(bl) && al[prefix] == bl[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(al)-prefix && suffix < len(bl)-prefix && al[len(al)-1-suffix] == bl[len(bl)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]DiffOp, 0, len(al)+len(bl))
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, DiffEqual)
+	}
+	ops = append(ops, myersOps(al[prefix:len(al)-suffix], bl[prefix:len(bl)-suffix])...)
+	for i := 0; i < suffix; i++ {
+		ops = append(ops, DiffEqual)
+	}
+
+	// 根据操作序列回放并生成带行号的结果
+	out := make([]DiffLine, 0, len(ops))
+	x, y := 0, 0
+	for _, op := range ops {
+		switch op {
+		case DiffEqual:
+			out = append(out, DiffLine{Op: op, OldLine: x + 1, NewLine: y + 1, Text: al[x]})
+			x++
+			y++
+		case DiffDelete:
+			out = append(out, DiffLine{Op: op, OldLine: x + 1, Text: al[x]})
+			x++
+		case DiffInsert:
+			out = append(out, DiffLine{Op: op, NewLine: y + 1, Text: bl[y]})
+			y++
+		}
+	}
+	return out
+}
+
+// splitLines 按行拆分文本；空字符串返回空切片。
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// myersOps 返回把 a 变为 b 的最短编辑操作序列。
+func myersOps(a, b []string) []DiffOp {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+	maxD := n + m
+	if maxD > maxDiffEditDistance {
+		maxD = maxDiffEditDistance
+	}
+	offset := n + m
+	v := make([]int, 2*(n+m)+2)
+	// trace[d] 保存第 d 轮开始前 k ∈ [-d, d] 的 v 值，下标为 k+d
+	var trace [][]int
+	found := -1
+	for d := 0; d <= maxD && found < 0; d++ {
+		snap := make([]int, 2*d+1)
+		copy(snap, v[offset-d:offset+d+1])
+		trace = append(trace, snap)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = d
+				break
+			}
+		}
+	}
+	if found < 0 {
+		// 超出搜索深度：整体替换
+		ops := make([]DiffOp, 0, n+m)
+		for i := 0; i < n; i++ {
+			ops = append(ops, DiffDelete)
+		}
+		for i := 0; i < m; i++ {
+			ops = append(ops, DiffInsert)
+		}
+		return ops
+	}
+
+	// 回溯得到逆序操作
+	var rev []DiffOp
+	x, y := n, m
+	for d := found; d > 0; d-- {
+		prev := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && prev[k-1+d] < prev[k+1+d]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := prev[prevK+d]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			rev = append(rev, DiffEqual)
+			x--
+			y--
+		}
+		if x == prevX {
+			rev = append(rev, DiffInsert)
+		} else {
+			rev = append(rev, DiffDelete)
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		rev = append(rev, DiffEqual)
+		x--
+		y--
+	}
+
+	ops := make([]DiffOp, len(rev))
+	for i := range rev {
+		ops[i] = rev[len(rev)-1-i]
+	}
+	return ops
+}
@@ -88,6 +88,21 @@ func Conflict(c *gin.Context, message string) {
 	JSON(c, http.StatusConflict, http.StatusConflict, message, nil, nil)
 }
 
+// ConflictWithData 返回 409 错误，并在 data 中附带服务端当前数据（如乐观锁冲突时的最新版本）。
+func ConflictWithData(c *gin.Context, message string, data interface{}) {
+	JSON(c, http.StatusConflict, http.StatusConflict, message, data, nil)
+}
+
+// Gone 返回 410 错误（资源曾经存在但已失效，例如到期的分享链接）。
+func Gone(c *gin.Context, message string) {
+	JSON(c, http.StatusGone, http.StatusGone, message, nil, nil)
+}
+
+// PreconditionRequired 返回 428 错误（缺少 If-Match 等前置条件）。
+func PreconditionRequired(c *gin.Context, message string) {
+	JSON(c, http.StatusPreconditionRequired, http.StatusPreconditionRequired, message, nil, nil)
+}
+
 // TooManyRequests 返回 429 错误（用于限流）
 func TooManyRequests(c *gin.Context, message string) {
 	JSON(c, http.StatusTooManyRequests, http.StatusTooManyRequests, message, nil, nil)
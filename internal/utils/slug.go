@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxSlugLength slug 主体部分的最大长度（不含去重后缀）
+const MaxSlugLength = 80
+
+// Slugify 根据标题生成 URL 友好的 slug：小写 ASCII 字母与数字，其余字符折叠为单个连字符。
+// 带变音符号的拉丁字母会去掉变音符号（é → e）；无法转写的字符（如中文）被丢弃，
+// 此时在末尾追加标题的短哈希，保证不同的中文标题得到不同的 slug。
+func Slugify(title string) string {
+	var b strings.Builder
+	dropped := false
+	pendingDash := false
+	for _, r := range norm.NFD.String(strings.ToLower(title)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// 变音符号：直接忽略
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if pendingDash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingDash = false
+			b.WriteRune(r)
+		default:
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				dropped = true
+			}
+			pendingDash = true
+		}
+	}
+	slug := b.String()
+	if len(slug) > MaxSlugLength {
+		slug = slug[:MaxSlugLength]
+		if i := strings.LastIndexByte(slug, '-'); i > MaxSlugLength/2 {
+			slug = slug[:i]
+		}
+		slug = strings.TrimRight(slug, "-")
+	}
+	if dropped {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(strings.TrimSpace(title)))
+		suffix := fmt.Sprintf("%08x", h.Sum32())
+		if slug == "" {
+			return suffix
+		}
+		return slug + "-" + suffix
+	}
+	if slug == "" {
+		return "note"
+	}
+	return slug
+}
@@ -0,0 +1,4 @@
+-- Revert 002_note_search.up.sql
+
+DROP INDEX IF EXISTS idx_notes_search_vector;
+ALTER TABLE notes DROP COLUMN IF EXISTS search_vector;
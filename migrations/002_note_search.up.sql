@@ -0,0 +1,13 @@
+-- Full-text search for notes: weighted tsvector column + GIN index
+-- The application refreshes search_vector on every note write using SEARCH_TS_CONFIG / SEARCH_CJK_SEGMENT.
+-- The backfill below matches the defaults (simple config, CJK characters split one per token);
+-- re-run it with the matching config if those settings are changed.
+
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+UPDATE notes SET search_vector =
+    setweight(to_tsvector('simple'::regconfig, regexp_replace(coalesce(title, ''), '([぀-ヿ㐀-䶿一-鿿豈-﫿가-힯])', ' \1 ', 'g')), 'A') ||
+    setweight(to_tsvector('simple'::regconfig, regexp_replace(coalesce(summary, ''), '([぀-ヿ㐀-䶿一-鿿豈-﫿가-힯])', ' \1 ', 'g')), 'B') ||
+    setweight(to_tsvector('simple'::regconfig, regexp_replace(coalesce(content, ''), '([぀-ヿ㐀-䶿一-鿿豈-﫿가-힯])', ' \1 ', 'g')), 'C');
+
+CREATE INDEX IF NOT EXISTS idx_notes_search_vector ON notes USING GIN (search_vector);
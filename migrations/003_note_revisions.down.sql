@@ -0,0 +1,3 @@
+-- Revert 003_note_revisions.up.sql
+
+DROP TABLE IF EXISTS note_revisions;
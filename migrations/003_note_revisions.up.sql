@@ -0,0 +1,16 @@
+-- Note revision history: one snapshot per note update, numbered per note
+
+CREATE TABLE IF NOT EXISTS note_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    note_id BIGINT NOT NULL REFERENCES notes(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    summary TEXT,
+    content TEXT NOT NULL,
+    tag_names JSONB,
+    editor_id BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_note_revisions_note_version ON note_revisions(note_id, version);
+CREATE INDEX IF NOT EXISTS idx_note_revisions_editor_id ON note_revisions(editor_id);
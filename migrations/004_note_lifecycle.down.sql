@@ -0,0 +1,10 @@
+-- Revert 004_note_lifecycle.up.sql
+
+DROP INDEX IF EXISTS idx_notes_expires_at;
+DROP INDEX IF EXISTS idx_notes_publish_at;
+DROP INDEX IF EXISTS idx_notes_status;
+
+ALTER TABLE notes DROP COLUMN IF EXISTS published_at;
+ALTER TABLE notes DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE notes DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE notes DROP COLUMN IF EXISTS status;
@@ -0,0 +1,15 @@
+-- Note publishing lifecycle: draft / scheduled / published / unpublished
+
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'draft';
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ;
+
+-- Existing public notes count as published since creation; private ones become drafts
+UPDATE notes
+SET status = CASE WHEN is_public THEN 'published' ELSE 'draft' END,
+    published_at = CASE WHEN is_public THEN created_at ELSE NULL END;
+
+CREATE INDEX IF NOT EXISTS idx_notes_status ON notes(status);
+CREATE INDEX IF NOT EXISTS idx_notes_publish_at ON notes(publish_at);
+CREATE INDEX IF NOT EXISTS idx_notes_expires_at ON notes(expires_at);
@@ -0,0 +1,5 @@
+-- Revert 005_note_slugs.up.sql
+
+DROP TABLE IF EXISTS note_slug_history;
+DROP INDEX IF EXISTS idx_notes_author_slug;
+ALTER TABLE notes DROP COLUMN IF EXISTS slug;
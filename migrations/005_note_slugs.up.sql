@@ -0,0 +1,23 @@
+-- Per-author note slugs (permalinks) and slug history for redirects
+
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS slug VARCHAR(100) NOT NULL DEFAULT '';
+
+-- Backfill: ASCII words of the title plus the note id (always unique per author);
+-- the application regenerates a clean slug the next time a note's title changes
+UPDATE notes
+SET slug = trim(both '-' from
+    left(lower(regexp_replace(title, '[^a-zA-Z0-9]+', '-', 'g')), 80) || '-' || id)
+WHERE slug = '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notes_author_slug ON notes(author_id, slug) WHERE slug <> '';
+
+CREATE TABLE IF NOT EXISTS note_slug_history (
+    id BIGSERIAL PRIMARY KEY,
+    note_id BIGINT NOT NULL REFERENCES notes(id) ON DELETE CASCADE,
+    author_id BIGINT NOT NULL,
+    slug VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_note_slug_history_author_slug ON note_slug_history(author_id, slug);
+CREATE INDEX IF NOT EXISTS idx_note_slug_history_note_id ON note_slug_history(note_id);
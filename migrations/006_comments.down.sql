@@ -0,0 +1,3 @@
+-- Revert 006_comments.up.sql
+
+DROP TABLE IF EXISTS comments;
@@ -0,0 +1,3 @@
+-- Revert 007_note_likes.up.sql (the previous anonymous like counts are not restored)
+
+DROP TABLE IF EXISTS note_likes;
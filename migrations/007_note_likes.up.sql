@@ -0,0 +1,13 @@
+-- One like per user per note; notes.likes becomes a denormalized count of note_likes
+
+CREATE TABLE IF NOT EXISTS note_likes (
+    note_id BIGINT NOT NULL REFERENCES notes(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (note_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_note_likes_user_id ON note_likes(user_id);
+
+-- Legacy likes were anonymous counters that cannot be attributed to users; start from the real count
+UPDATE notes SET likes = 0;
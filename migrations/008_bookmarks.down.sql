@@ -0,0 +1,3 @@
+-- Revert 008_bookmarks.up.sql
+
+DROP TABLE IF EXISTS bookmarks;
@@ -0,0 +1,15 @@
+-- Per-user bookmarks (reading list) with optional folder and private annotation
+
+CREATE TABLE IF NOT EXISTS bookmarks (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    note_id BIGINT NOT NULL REFERENCES notes(id) ON DELETE CASCADE,
+    folder VARCHAR(64) NOT NULL DEFAULT '',
+    annotation TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookmarks_user_note ON bookmarks(user_id, note_id);
+CREATE INDEX IF NOT EXISTS idx_bookmarks_user_folder ON bookmarks(user_id, folder);
+CREATE INDEX IF NOT EXISTS idx_bookmarks_note_id ON bookmarks(note_id);
@@ -0,0 +1,4 @@
+-- Revert 009_series.up.sql
+
+DROP TABLE IF EXISTS series_notes;
+DROP TABLE IF EXISTS series;
@@ -0,0 +1,21 @@
+-- Author-owned note series with an ordered membership; a note belongs to at most one series
+
+CREATE TABLE IF NOT EXISTS series (
+    id BIGSERIAL PRIMARY KEY,
+    author_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_series_author_id ON series(author_id);
+
+CREATE TABLE IF NOT EXISTS series_notes (
+    series_id BIGINT NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    note_id BIGINT NOT NULL REFERENCES notes(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (series_id, note_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_series_notes_note_id ON series_notes(note_id);
@@ -0,0 +1,3 @@
+-- Revert 010_note_links.up.sql
+
+DROP TABLE IF EXISTS note_links;
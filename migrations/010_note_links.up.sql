@@ -0,0 +1,14 @@
+-- Directed links between notes parsed from content ([[id]], [[Title]], /notes/:id).
+-- target_id has no foreign key on purpose: links may point at notes that were deleted or never existed.
+-- Existing notes get their links the next time they are saved.
+
+CREATE TABLE IF NOT EXISTS note_links (
+    id BIGSERIAL PRIMARY KEY,
+    source_id BIGINT NOT NULL REFERENCES notes(id) ON DELETE CASCADE,
+    target_id BIGINT,
+    target_title VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_note_links_source_id ON note_links(source_id);
+CREATE INDEX IF NOT EXISTS idx_note_links_target_id ON note_links(target_id);
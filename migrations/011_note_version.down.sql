@@ -0,0 +1,3 @@
+-- Revert 011_note_version.up.sql
+
+ALTER TABLE notes DROP COLUMN IF EXISTS version;
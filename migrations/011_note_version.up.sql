@@ -0,0 +1,3 @@
+-- Optimistic concurrency: every content or publishing-state change bumps notes.version
+
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
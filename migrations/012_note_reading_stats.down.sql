@@ -0,0 +1,4 @@
+-- Revert 012_note_reading_stats.up.sql
+
+ALTER TABLE notes DROP COLUMN IF EXISTS reading_minutes;
+ALTER TABLE notes DROP COLUMN IF EXISTS word_count;
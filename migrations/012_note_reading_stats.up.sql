@@ -0,0 +1,5 @@
+-- Word count (CJK characters counted individually, other text by word) and estimated reading time.
+-- Computed by the application on save; run `go run ./cmd/backfill-reading-stats` to fill existing rows.
+
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS word_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS reading_minutes INTEGER NOT NULL DEFAULT 0;
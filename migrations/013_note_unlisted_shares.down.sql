@@ -0,0 +1,4 @@
+-- Revert 013_note_unlisted_shares.up.sql
+
+DROP TABLE IF EXISTS note_shares;
+ALTER TABLE notes DROP COLUMN IF EXISTS unlisted;
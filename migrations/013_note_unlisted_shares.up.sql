@@ -0,0 +1,21 @@
+-- Unlisted visibility: published notes readable by URL but excluded from public listings, feeds, sitemaps,
+-- related notes, trending and backlinks.
+-- Share links: random tokens that let anyone read a note without authentication, with optional expiry,
+-- bcrypt-hashed password and maximum number of views. Revoking a link deletes its row.
+
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS unlisted BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS note_shares (
+    id BIGSERIAL PRIMARY KEY,
+    note_id BIGINT NOT NULL REFERENCES notes(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL,
+    password_hash VARCHAR(100) NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ,
+    max_views INTEGER,
+    views INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_note_shares_token ON note_shares(token);
+CREATE INDEX IF NOT EXISTS idx_note_shares_note_id ON note_shares(note_id);